@@ -0,0 +1,269 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/italorfeitosa/payee-account-manager-api/internal/api"
+	"github.com/italorfeitosa/payee-account-manager-api/internal/application"
+	"github.com/italorfeitosa/payee-account-manager-api/internal/config"
+	"github.com/italorfeitosa/payee-account-manager-api/internal/infra/encryption"
+	"github.com/italorfeitosa/payee-account-manager-api/internal/infra/jwks"
+	"github.com/italorfeitosa/payee-account-manager-api/internal/infra/logging"
+	"github.com/italorfeitosa/payee-account-manager-api/internal/infra/memory"
+	"github.com/italorfeitosa/payee-account-manager-api/internal/infra/receita"
+	"github.com/italorfeitosa/payee-account-manager-api/internal/infra/smtp"
+	"github.com/italorfeitosa/payee-account-manager-api/internal/infra/tracing"
+)
+
+func main() {
+	if err := run(); err != nil {
+		slog.Error("api stopped with error", slog.String("error", err.Error()))
+		os.Exit(1)
+	}
+}
+
+func run() error {
+	slog.SetDefault(slog.New(logging.NewRedactingHandler(slog.NewJSONHandler(os.Stdout, nil))))
+
+	cfg, err := config.Load(os.LookupEnv)
+	if err != nil {
+		return err
+	}
+
+	if cfg.Tracing.Exporter == config.StdoutTracingExporter {
+		provider, err := tracing.NewStdoutProvider(os.Stdout)
+		if err != nil {
+			return fmt.Errorf("tracing: %w", err)
+		}
+
+		tracing.Setup(provider)
+		defer func() {
+			if err := provider.Shutdown(context.Background()); err != nil {
+				slog.Error("failed to flush spans", slog.String("error", err.Error()))
+			}
+		}()
+	}
+
+	keys, err := encryption.NewFileKeyProvider(cfg.Encryption.KeysFile)
+	if err != nil {
+		return fmt.Errorf("encryption: %w", err)
+	}
+
+	store := memory.NewPayeeRepository(encryption.NewFieldEncrypter(keys))
+	idempotency := memory.NewIdempotencyStore(cfg.Idempotency.TTL.Duration, cfg.Idempotency.Lease.Duration)
+
+	metrics := api.NewMetrics()
+
+	health, router, err := newRouter(cfg, store, idempotency, metrics)
+	if err != nil {
+		return err
+	}
+
+	server := &http.Server{
+		Addr:         cfg.HTTP.Addr,
+		Handler:      router,
+		ReadTimeout:  cfg.HTTP.ReadTimeout.Duration,
+		WriteTimeout: cfg.HTTP.WriteTimeout.Duration,
+	}
+
+	// metrics are labeled by tenant, so they are served on an internal listener, not exposed with api
+	metricsMux := http.NewServeMux()
+	metricsMux.Handle("GET /metrics", metrics.Handler())
+	metricsServer := &http.Server{
+		Addr:         cfg.HTTP.MetricsAddr,
+		Handler:      metricsMux,
+		ReadTimeout:  cfg.HTTP.ReadTimeout.Duration,
+		WriteTimeout: cfg.HTTP.WriteTimeout.Duration,
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGTERM, os.Interrupt)
+	defer stop()
+
+	go rotateKeysOnHangup(ctx, keys, store)
+	go deleteExpiredIdempotencyKeys(ctx, idempotency)
+
+	serverErr := make(chan error, 1)
+	go func() {
+		slog.Info("api listening", slog.String("addr", cfg.HTTP.Addr))
+		serverErr <- server.ListenAndServe()
+	}()
+
+	metricsErr := make(chan error, 1)
+	go func() {
+		slog.Info("metrics listening", slog.String("addr", cfg.HTTP.MetricsAddr))
+		metricsErr <- metricsServer.ListenAndServe()
+	}()
+
+	select {
+	case err := <-serverErr:
+		return fmt.Errorf("listen: %w", err)
+	case err := <-metricsErr:
+		return fmt.Errorf("metrics listen: %w", err)
+	case <-ctx.Done():
+	}
+
+	slog.Info("shutting down, draining in-flight requests",
+		slog.Duration("drain_delay", cfg.HTTP.DrainDelay.Duration),
+		slog.Duration("timeout", cfg.HTTP.ShutdownTimeout.Duration))
+	health.Drain()
+
+	// keeps serving while load balancers see /readyz failing, so no request reaches a closed listener
+	time.Sleep(cfg.HTTP.DrainDelay.Duration)
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), cfg.HTTP.ShutdownTimeout.Duration)
+	defer cancel()
+
+	if err := server.Shutdown(shutdownCtx); err != nil {
+		return fmt.Errorf("shutdown: %w", err)
+	}
+
+	if err := <-serverErr; !errors.Is(err, http.ErrServerClosed) {
+		return fmt.Errorf("listen: %w", err)
+	}
+
+	// metrics are stopped last, so requests drained above are still scraped until then
+	if err := metricsServer.Shutdown(shutdownCtx); err != nil {
+		return fmt.Errorf("metrics shutdown: %w", err)
+	}
+
+	if err := <-metricsErr; !errors.Is(err, http.ErrServerClosed) {
+		return fmt.Errorf("metrics listen: %w", err)
+	}
+
+	slog.Info("api stopped")
+
+	return nil
+}
+
+// rotateKeysOnHangup reloads encryption keys on SIGHUP, and rewraps the data keys of stored payees
+// with the current key, so keys rotate while requests are served
+func rotateKeysOnHangup(ctx context.Context, keys *encryption.FileKeyProvider, store *memory.PayeeRepository) {
+	hangup := make(chan os.Signal, 1)
+	signal.Notify(hangup, syscall.SIGHUP)
+	defer signal.Stop(hangup)
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-hangup:
+		}
+
+		if err := keys.Reload(); err != nil {
+			slog.Error("failed to reload encryption keys, previous keys are kept", slog.String("error", err.Error()))
+			continue
+		}
+
+		rewrapped, err := store.RewrapDataKeys(ctx)
+		if err != nil {
+			slog.Error("failed to rewrap data keys", slog.Int("rewrapped", rewrapped), slog.String("error", err.Error()))
+			continue
+		}
+
+		slog.Info("encryption keys rotated", slog.Int("rewrapped", rewrapped))
+	}
+}
+
+// idempotencySweepInterval is how often expired idempotency keys are dropped from memory
+const idempotencySweepInterval = time.Minute
+
+// deleteExpiredIdempotencyKeys drops expired idempotency keys periodically, so requests don't pay for it
+func deleteExpiredIdempotencyKeys(ctx context.Context, idempotency *memory.IdempotencyStore) {
+	ticker := time.NewTicker(idempotencySweepInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		idempotency.DeleteExpired()
+	}
+}
+
+// newRouter wires adapters, use cases and handlers
+func newRouter(cfg config.Config, store *memory.PayeeRepository, idempotency *memory.IdempotencyStore, metrics *api.Metrics) (*api.HealthHandler, http.Handler, error) {
+	repository := tracing.NewPayeeRepository(store)
+
+	var checker application.DocumentStatusChecker
+	if cfg.Receita.StatusFile != "" {
+		fileChecker, err := receita.NewFileStatusChecker(cfg.Receita.StatusFile)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		checker = fileChecker
+	}
+
+	var mailer application.Mailer = smtp.DiscardMailer{}
+	if cfg.SMTP.Addr != "" {
+		mailer = smtp.NewMailer(cfg.SMTP.Addr, cfg.SMTP.From, cfg.SMTP.Username, cfg.SMTP.Password, cfg.SMTP.Timeout.Duration)
+	} else {
+		slog.Warn("smtp addr is not set, emails are discarded and not delivered")
+	}
+
+	tokens := application.NewEmailVerificationTokens([]byte(cfg.EmailVerification.Secret), cfg.EmailVerification.TTL.Duration)
+
+	// probes are frequent, so readiness pings the store without creating spans
+	health := api.NewHealthHandler(map[string]api.ReadinessCheck{"repository": store.Ping})
+	limiter := api.NewRateLimiter(func(tenantID string) api.RateLimits {
+		limits := cfg.RateLimit.For(tenantID)
+
+		return api.RateLimits{
+			ReadsPerSecond:  limits.ReadsPerSecond,
+			ReadBurst:       limits.ReadBurst,
+			WritesPerSecond: limits.WritesPerSecond,
+			WriteBurst:      limits.WriteBurst,
+		}
+	})
+	keySet, err := jwks.LoadFile(cfg.Auth.JWKSFile)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	audit := memory.NewAuditLog()
+	quota := func(tenantID string) int {
+		return cfg.RateLimit.For(tenantID).DailyRegistrations
+	}
+
+	router := api.NewRouter(
+		api.NewPayeeHandler(
+			application.NewRegisterPayeeUseCase(repository, quota, idempotency, audit),
+			application.NewEditPayeeUseCase(repository, audit),
+			application.NewListPayeesUseCase(repository),
+			application.NewDeletePayeesUseCase(repository, audit),
+			application.NewValidatePayeeUseCase(repository, checker, audit),
+			metrics,
+		),
+		api.NewPixKeyHandler(
+			application.NewAddPixKeyUseCase(repository, audit),
+			application.NewRemovePixKeyUseCase(repository, audit),
+			application.NewSetPrimaryPixKeyUseCase(repository, audit),
+		),
+		api.NewCompanyHandler(application.NewGroupPayeesByCompanyUseCase(repository)),
+		api.NewEmailVerificationHandler(
+			application.NewSendEmailVerificationUseCase(repository, mailer, tokens, audit, cfg.EmailVerification.ConfirmationURL),
+			application.NewConfirmEmailUseCase(repository, tokens, audit),
+		),
+		api.NewDataSubjectHandler(
+			application.NewExportDataSubjectUseCase(repository, audit),
+			application.NewAnonymizeDataSubjectUseCase(repository, audit),
+		),
+		health,
+		metrics,
+		limiter,
+		api.NewAuthenticator(keySet.Keyfunc, cfg.Auth.Issuer, cfg.Auth.Audience),
+	)
+
+	return health, router, nil
+}
@@ -0,0 +1,111 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"strings"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+	"github.com/italorfeitosa/payee-account-manager-api/internal/application"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// TenantClaim is the bearer token claim with the tenant id of authenticated subject
+const TenantClaim = "tenant_id"
+
+// signingMethods are the asymmetric algorithms accepted, so a public key is never used as HMAC secret
+var signingMethods = []string{"RS256", "RS384", "RS512", "PS256", "PS384", "PS512", "ES256", "ES384", "ES512", "EdDSA"}
+
+// RolesClaim is the bearer token claim with the application.Role list of authenticated subject
+const RolesClaim = "roles"
+
+type tokenClaims struct {
+	jwt.RegisteredClaims
+	TenantID string   `json:"tenant_id"`
+	Roles    []string `json:"roles"`
+}
+
+// roles returns the known roles of claims, unknown ones are ignored
+// so identity providers can share tokens with other apps
+func (c tokenClaims) roles() []application.Role {
+	var roles []application.Role
+	for _, name := range c.Roles {
+		if role, ok := application.ParseRole(name); ok {
+			roles = append(roles, role)
+		}
+	}
+
+	return roles
+}
+
+// Authenticator verifies the bearer token of tenant scoped requests
+type Authenticator struct {
+	keyfunc jwt.Keyfunc
+	parser  *jwt.Parser
+}
+
+// NewAuthenticator returns the authenticator, keyfunc returns the public key of token kid.
+// Issuer and audience are optional, when set tokens must have them in iss and aud claims
+func NewAuthenticator(keyfunc jwt.Keyfunc, issuer, audience string) *Authenticator {
+	opts := []jwt.ParserOption{jwt.WithValidMethods(signingMethods), jwt.WithExpirationRequired()}
+	if issuer != "" {
+		opts = append(opts, jwt.WithIssuer(issuer))
+	}
+	if audience != "" {
+		opts = append(opts, jwt.WithAudience(audience))
+	}
+
+	return &Authenticator{keyfunc, jwt.NewParser(opts...)}
+}
+
+// authenticate rejects requests without a valid bearer token, with a subject and a uuid in tenant_id claim.
+// tenant-id header is optional, but must match the claim when sent. Tenant and subject, as actor with
+// roles claim, are stored in request context and in the attributes of request span. Tokens without
+// roles are authenticated, but use cases reject them as forbidden
+func (a *Authenticator) authenticate(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+		if !ok || token == "" {
+			// without credentials there is no error code, as in RFC 6750
+			w.Header().Set("WWW-Authenticate", "Bearer")
+			writeError(w, http.StatusUnauthorized, "missing bearer token")
+			return
+		}
+
+		var claims tokenClaims
+		if _, err := a.parser.ParseWithClaims(token, &claims, a.keyfunc); err != nil {
+			writeUnauthorized(w, "invalid bearer token")
+			return
+		}
+
+		if claims.Subject == "" {
+			writeUnauthorized(w, "bearer token without sub claim")
+			return
+		}
+
+		if _, err := uuid.Parse(claims.TenantID); err != nil {
+			writeUnauthorized(w, "bearer token without valid tenant_id claim")
+			return
+		}
+
+		if header := r.Header.Get(TenantIDHeader); header != "" && header != claims.TenantID {
+			writeError(w, http.StatusForbidden, "tenant-id header does not match bearer token tenant")
+			return
+		}
+
+		trace.SpanFromContext(r.Context()).SetAttributes(
+			application.TenantIDAttribute.String(claims.TenantID),
+			application.ActorAttribute.String(claims.Subject),
+		)
+
+		ctx := context.WithValue(r.Context(), tenantContextKey{}, claims.TenantID)
+		ctx = application.WithActor(ctx, claims.Subject, claims.roles()...)
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
+func writeUnauthorized(w http.ResponseWriter, message string) {
+	w.Header().Set("WWW-Authenticate", `Bearer error="invalid_token"`)
+	writeError(w, http.StatusUnauthorized, message)
+}
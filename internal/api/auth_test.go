@@ -0,0 +1,176 @@
+package api_test
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+	"github.com/italorfeitosa/payee-account-manager-api/internal/api"
+	"github.com/italorfeitosa/payee-account-manager-api/internal/application"
+	"github.com/italorfeitosa/payee-account-manager-api/internal/infra/memory"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	testIssuer   = "https://auth.payees.com"
+	testAudience = "payee-account-manager-api"
+	testSubject  = "italo@payees.com"
+)
+
+var testPublicKey, testSigningKey, _ = ed25519.GenerateKey(rand.Reader)
+
+func testKeyfunc(*jwt.Token) (any, error) {
+	return testPublicKey, nil
+}
+
+// testClaims are valid claims of testSubject in tenant, as admin when no role is given
+func testClaims(tenantID string, roles ...application.Role) jwt.MapClaims {
+	if len(roles) == 0 {
+		roles = []application.Role{application.AdminRole}
+	}
+
+	return jwt.MapClaims{
+		"iss":           testIssuer,
+		"aud":           testAudience,
+		"sub":           testSubject,
+		"exp":           time.Now().Add(time.Hour).Unix(),
+		api.TenantClaim: tenantID,
+		api.RolesClaim:  roles,
+	}
+}
+
+func signToken(claims jwt.MapClaims, key any) string {
+	token, err := jwt.NewWithClaims(jwt.SigningMethodEdDSA, claims).SignedString(key)
+	if err != nil {
+		panic(err)
+	}
+
+	return token
+}
+
+// authorize sets a valid bearer token of testSubject in tenant, as admin when no role is given
+func authorize(req *http.Request, tenantID string, roles ...application.Role) {
+	req.Header.Set("Authorization", "Bearer "+signToken(testClaims(tenantID, roles...), testSigningKey))
+}
+
+func TestAuthenticator(t *testing.T) {
+	router := newTestRouter()
+	_, otherKey, _ := ed25519.GenerateKey(rand.Reader)
+
+	tokenWith := func(mutate func(jwt.MapClaims)) func(tenantID string) string {
+		return func(tenantID string) string {
+			claims := testClaims(tenantID)
+			mutate(claims)
+			return "Bearer " + signToken(claims, testSigningKey)
+		}
+	}
+	validToken := tokenWith(func(jwt.MapClaims) {})
+	sameTenant := func(tenantID string) string { return tenantID }
+	noHeader := func(string) string { return "" }
+
+	tests := []struct {
+		name          string
+		authorization func(tenantID string) string
+		tenantHeader  func(tenantID string) string
+		wantStatus    int
+	}{
+		{"given valid token should return 201", validToken, noHeader, http.StatusCreated},
+		{"given tenant-id header matching token should return 201", validToken, sameTenant, http.StatusCreated},
+		{"given tenant-id header of another tenant should return 403", validToken, func(string) string { return uuid.NewString() }, http.StatusForbidden},
+		{"given no token should return 401", func(string) string { return "" }, sameTenant, http.StatusUnauthorized},
+		{"given basic credentials should return 401", func(string) string { return "Basic aXRhbG86ZmVpdG9zYQ==" }, noHeader, http.StatusUnauthorized},
+		{"given token signed by another key should return 401", func(tenantID string) string {
+			return "Bearer " + signToken(testClaims(tenantID), otherKey)
+		}, noHeader, http.StatusUnauthorized},
+		{"given unsigned token should return 401", func(tenantID string) string {
+			return "Bearer " + unsignedToken(testClaims(tenantID))
+		}, noHeader, http.StatusUnauthorized},
+		{"given expired token should return 401", tokenWith(func(c jwt.MapClaims) { c["exp"] = time.Now().Add(-time.Minute).Unix() }), noHeader, http.StatusUnauthorized},
+		{"given token without exp should return 401", tokenWith(func(c jwt.MapClaims) { delete(c, "exp") }), noHeader, http.StatusUnauthorized},
+		{"given token of another issuer should return 401", tokenWith(func(c jwt.MapClaims) { c["iss"] = "https://evil.com" }), noHeader, http.StatusUnauthorized},
+		{"given token of another audience should return 401", tokenWith(func(c jwt.MapClaims) { c["aud"] = "other-api" }), noHeader, http.StatusUnauthorized},
+		{"given token without sub should return 401", tokenWith(func(c jwt.MapClaims) { delete(c, "sub") }), noHeader, http.StatusUnauthorized},
+		{"given token with invalid tenant claim should return 401", tokenWith(func(c jwt.MapClaims) { c[api.TenantClaim] = "invalid" }), noHeader, http.StatusUnauthorized},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tenantID := uuid.NewString()
+
+			req := httptest.NewRequest(http.MethodPost, "/api/v1/payees", strings.NewReader(validPayeeBody))
+			if authorization := tt.authorization(tenantID); authorization != "" {
+				req.Header.Set("Authorization", authorization)
+			}
+			if header := tt.tenantHeader(tenantID); header != "" {
+				req.Header.Set(api.TenantIDHeader, header)
+			}
+
+			rec := httptest.NewRecorder()
+			router.ServeHTTP(rec, req)
+
+			assert.Equal(t, tt.wantStatus, rec.Code, rec.Body.String())
+			if tt.wantStatus == http.StatusUnauthorized {
+				assert.True(t, strings.HasPrefix(rec.Header().Get("WWW-Authenticate"), "Bearer"))
+			}
+		})
+	}
+}
+
+func TestAuthenticator_Actor(t *testing.T) {
+	audit := memory.NewAuditLog()
+	router := newConfiguredTestRouter(nil, memory.NewMailer(), api.NewMetrics(), func(string) api.RateLimits { return testRateLimits }, nil, audit)
+	tenantID := uuid.NewString()
+
+	id := registerPayee(t, router, tenantID, validPayeeBody)
+	rec := doRequest(router, http.MethodPost, "/api/v1/payees/"+id+"/validate", tenantID, validateBody)
+	require.Equal(t, http.StatusNoContent, rec.Code, rec.Body.String())
+
+	entries, err := audit.List(context.Background(), tenantID, id)
+	require.NoError(t, err)
+
+	t.Run("given authenticated requests should record token subject as actor", func(t *testing.T) {
+		require.Len(t, entries, 2)
+		assert.Equal(t, application.PayeeRegisteredAction, entries[0].Action)
+		assert.Equal(t, application.PayeeValidatedAction, entries[1].Action)
+
+		for _, entry := range entries {
+			assert.Equal(t, testSubject, entry.Actor)
+			assert.Equal(t, tenantID, entry.TenantID)
+		}
+	})
+
+	t.Run("given unknown and already deleted ids should audit only deleted payees", func(t *testing.T) {
+		unknownID := uuid.NewString()
+
+		rec := doRequest(router, http.MethodDelete, "/api/v1/payees", tenantID, `{"ids": ["`+id+`", "`+unknownID+`"]}`)
+		require.Equal(t, http.StatusNoContent, rec.Code, rec.Body.String())
+		rec = doRequest(router, http.MethodDelete, "/api/v1/payees", tenantID, `{"ids": ["`+id+`"]}`)
+		require.Equal(t, http.StatusNoContent, rec.Code, rec.Body.String())
+
+		entries, err := audit.List(context.Background(), tenantID, id)
+		require.NoError(t, err)
+		require.Len(t, entries, 3)
+		assert.Equal(t, application.PayeeDeletedAction, entries[2].Action)
+
+		entries, err = audit.List(context.Background(), tenantID, unknownID)
+		require.NoError(t, err)
+		assert.Empty(t, entries)
+	})
+}
+
+func unsignedToken(claims jwt.MapClaims) string {
+	token, err := jwt.NewWithClaims(jwt.SigningMethodNone, claims).SignedString(jwt.UnsafeAllowNoneSignatureType)
+	if err != nil {
+		panic(err)
+	}
+
+	return token
+}
@@ -0,0 +1,192 @@
+package api_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/italorfeitosa/payee-account-manager-api/internal/application"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func doRequestAs(router http.Handler, method, target, tenantID, body string, role application.Role) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, target, strings.NewReader(body))
+	authorize(req, tenantID, role)
+
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+
+	return rec
+}
+
+func TestAuthorization(t *testing.T) {
+	router := newTestRouter()
+
+	type operation struct {
+		method     string
+		target     func(payeeID string) string
+		body       func(payeeID string) string
+		wantStatus int
+	}
+	payeePath := func(suffix string) func(string) string {
+		return func(payeeID string) string { return "/api/v1/payees/" + payeeID + suffix }
+	}
+	body := func(b string) func(string) string {
+		return func(string) string { return b }
+	}
+
+	operations := map[string]operation{
+		"list":     {http.MethodGet, func(string) string { return "/api/v1/payees" }, body(""), http.StatusOK},
+		"register": {http.MethodPost, func(string) string { return "/api/v1/payees" }, body(validPayeeBody), http.StatusCreated},
+		"edit":     {http.MethodPut, payeePath(""), body(validPayeeBody), http.StatusNoContent},
+		"validate": {http.MethodPost, payeePath("/validate"), body(validateBody), http.StatusNoContent},
+		"delete": {http.MethodDelete, func(string) string { return "/api/v1/payees" }, func(payeeID string) string {
+			return `{"ids": ["` + payeeID + `"]}`
+		}, http.StatusNoContent},
+		"export data subject":    {http.MethodPost, func(string) string { return "/api/v1/data-subjects/export" }, body(dataSubjectBody), http.StatusOK},
+		"anonymize data subject": {http.MethodPost, func(string) string { return "/api/v1/data-subjects/anonymize" }, body(dataSubjectBody), http.StatusOK},
+	}
+
+	allowed := map[application.Role][]string{
+		application.ViewerRole:   {"list"},
+		application.OperatorRole: {"list", "register", "edit"},
+		application.ApproverRole: {"list", "validate"},
+		application.AdminRole:    {"list", "register", "edit", "validate", "delete", "export data subject", "anonymize data subject"},
+	}
+
+	for role, allowedOperations := range allowed {
+		for name, op := range operations {
+			isAllowed := false
+			for _, allowedOperation := range allowedOperations {
+				isAllowed = isAllowed || allowedOperation == name
+			}
+
+			testName := "given " + string(role) + " should be forbidden to " + name
+			wantStatus := http.StatusForbidden
+			if isAllowed {
+				testName = "given " + string(role) + " should be allowed to " + name
+				wantStatus = op.wantStatus
+			}
+
+			t.Run(testName, func(t *testing.T) {
+				// each case has its own tenant, so registrations don't conflict
+				tenantID := uuid.NewString()
+				payeeID := uuid.NewString()
+				if name != "register" {
+					payeeID = registerPayee(t, router, tenantID, validPayeeBody)
+				}
+
+				rec := doRequestAs(router, op.method, op.target(payeeID), tenantID, op.body(payeeID), role)
+
+				assert.Equal(t, wantStatus, rec.Code, rec.Body.String())
+			})
+		}
+	}
+
+	t.Run("given token without roles should be forbidden to list", func(t *testing.T) {
+		claims := testClaims(uuid.NewString())
+		delete(claims, "roles")
+
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/payees", nil)
+		req.Header.Set("Authorization", "Bearer "+signToken(claims, testSigningKey))
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusForbidden, rec.Code)
+	})
+
+	t.Run("given unknown roles should ignore them", func(t *testing.T) {
+		claims := testClaims(uuid.NewString())
+		claims["roles"] = []string{"superuser", string(application.ViewerRole)}
+
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/payees", strings.NewReader(validPayeeBody))
+		req.Header.Set("Authorization", "Bearer "+signToken(claims, testSigningKey))
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusForbidden, rec.Code)
+	})
+}
+
+func TestAuthorization_FieldVisibility(t *testing.T) {
+	router := newTestRouter()
+	tenantID := uuid.NewString()
+	body := strings.Replace(validPayeeBody, `"name": "Italo Feitosa",`, `"name": "Italo Feitosa",
+		"contacts": [{"type": "PHONE", "value": "(11) 91234-5678", "primary": true}, {"type": "EMAIL", "value": "contato@feitosa.com"}],`, 1)
+	id := registerPayee(t, router, tenantID, body)
+	rec := doRequest(router, http.MethodPost, "/api/v1/payees/"+id+"/validate", tenantID, validateBody)
+	require.Equal(t, http.StatusNoContent, rec.Code, rec.Body.String())
+
+	listAs := func(t *testing.T, role application.Role, query string) map[string]any {
+		t.Helper()
+
+		rec := doRequestAs(router, http.MethodGet, "/api/v1/payees"+query, tenantID, "", role)
+		require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+
+		var response listResponse
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &response))
+		require.Len(t, response.Data, 1)
+
+		return response.Data[0]
+	}
+
+	t.Run("given viewer should mask cpf_cnpj, contacts, pix keys and account number", func(t *testing.T) {
+		payee := listAs(t, application.ViewerRole, "")
+
+		contacts := payee["contacts"].([]any)
+		assert.Equal(t, "+55 (11) 9****-5678", contacts[0].(map[string]any)["value"])
+		assert.Equal(t, "c***@feitosa.com", contacts[1].(map[string]any)["value"])
+
+		assert.Equal(t, "***.180.830-**", payee["cpf_cnpj"])
+		assert.Equal(t, "***.180.830-**", payee["pix_key"])
+		assert.Equal(t, "***.180.830-**", payee["pix_keys"].([]any)[0].(map[string]any)["pix_key"])
+		assert.Equal(t, "******65", payee["bank_account"].(map[string]any)["account_number"])
+	})
+
+	t.Run("given viewer asking masked=false should still mask", func(t *testing.T) {
+		payee := listAs(t, application.ViewerRole, "?masked=false")
+
+		assert.Equal(t, "***.180.830-**", payee["cpf_cnpj"])
+	})
+
+	t.Run("given operator should return unmasked fields", func(t *testing.T) {
+		payee := listAs(t, application.OperatorRole, "")
+
+		assert.Equal(t, "99818083008", payee["cpf_cnpj"])
+		assert.Equal(t, "99818083008", payee["pix_key"])
+		assert.Equal(t, "65465465", payee["bank_account"].(map[string]any)["account_number"])
+		assert.Equal(t, "5511912345678", payee["contacts"].([]any)[0].(map[string]any)["value"])
+	})
+
+	companyTenantID := uuid.NewString()
+	registerPayee(t, router, companyTenantID, companyPayeeBody("Company Headquarters", "11.222.333/0001-81"))
+
+	companyDocumentAs := func(t *testing.T, role application.Role) any {
+		t.Helper()
+
+		rec := doRequestAs(router, http.MethodGet, "/api/v1/companies", companyTenantID, "", role)
+		require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+
+		var response struct {
+			Data []struct {
+				Branches []map[string]any `json:"branches"`
+			} `json:"data"`
+		}
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &response))
+		require.Len(t, response.Data, 1)
+
+		return response.Data[0].Branches[0]["cpf_cnpj"]
+	}
+
+	t.Run("given viewer should mask cnpj of companies", func(t *testing.T) {
+		assert.Equal(t, "**.222.333/0001-**", companyDocumentAs(t, application.ViewerRole))
+	})
+
+	t.Run("given operator should return cnpj of companies unmasked", func(t *testing.T) {
+		assert.Equal(t, "11222333000181", companyDocumentAs(t, application.OperatorRole))
+	})
+}
@@ -0,0 +1,70 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/italorfeitosa/payee-account-manager-api/internal/application"
+)
+
+type companyBranchResponse struct {
+	PayeeID      string `json:"payee_id"`
+	Name         string `json:"name"`
+	Document     string `json:"cpf_cnpj"`
+	BranchOrder  string `json:"branch_order"`
+	Headquarters bool   `json:"headquarters"`
+	Status       string `json:"status"`
+}
+
+type companyResponse struct {
+	CNPJRoot            string                  `json:"cnpj_root"`
+	HeadquartersPayeeID *string                 `json:"headquarters_payee_id"`
+	Branches            []companyBranchResponse `json:"branches"`
+}
+
+// CompanyHandler handles http requests of payees grouped by company
+type CompanyHandler struct {
+	groupPayeesByCompany *application.GroupPayeesByCompanyUseCase
+}
+
+func NewCompanyHandler(groupPayeesByCompany *application.GroupPayeesByCompanyUseCase) *CompanyHandler {
+	return &CompanyHandler{groupPayeesByCompany}
+}
+
+// List handles GET api/v1/companies, CNPJs are masked for actors without permission to view sensitive data
+func (h *CompanyHandler) List(w http.ResponseWriter, r *http.Request) {
+	output, err := h.groupPayeesByCompany.Execute(r.Context(), tenantFromContext(r.Context()))
+	if err != nil {
+		writeUseCaseError(w, err)
+		return
+	}
+
+	companies := make([]companyResponse, 0, len(output.Companies))
+	for _, group := range output.Companies {
+		company := companyResponse{CNPJRoot: group.CNPJRoot}
+
+		if headquarters := group.Headquarters(); headquarters != nil {
+			id := headquarters.ID()
+			company.HeadquartersPayeeID = &id
+		}
+
+		for _, branch := range group.Branches {
+			document := branch.CNPJ.Value()
+			if output.Masked {
+				document = branch.CNPJ.Masked()
+			}
+
+			company.Branches = append(company.Branches, companyBranchResponse{
+				PayeeID:      branch.Payee.ID(),
+				Name:         branch.Payee.Name(),
+				Document:     document,
+				BranchOrder:  branch.CNPJ.BranchOrder(),
+				Headquarters: branch.CNPJ.IsHeadquarters(),
+				Status:       branch.Payee.Status().Value(),
+			})
+		}
+
+		companies = append(companies, company)
+	}
+
+	writeJSON(w, http.StatusOK, dataResponse{Data: companies})
+}
@@ -0,0 +1,65 @@
+package api_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func companyPayeeBody(name, cnpj string) string {
+	return fmt.Sprintf(`{"name": %q, "cpf_cnpj": %q, "pix_key_type": "CNPJ", "pix_key": %q}`, name, cnpj, cnpj)
+}
+
+func TestCompanyHandler_List(t *testing.T) {
+	router := newTestRouter()
+	tenantID := uuid.NewString()
+
+	branchID := registerPayee(t, router, tenantID, companyPayeeBody("Company Branch", "11.222.333/0002-62"))
+	headquartersID := registerPayee(t, router, tenantID, companyPayeeBody("Company Headquarters", "11.222.333/0001-81"))
+	otherID := registerPayee(t, router, tenantID, companyPayeeBody("Other Company", "19.039.318/0001-04"))
+	registerPayee(t, router, tenantID, validPayeeBody)
+
+	t.Run("should group payees by cnpj root with headquarters first", func(t *testing.T) {
+		rec := doRequest(router, http.MethodGet, "/api/v1/companies", tenantID, "")
+		require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+
+		var response struct {
+			Data []struct {
+				CNPJRoot            string  `json:"cnpj_root"`
+				HeadquartersPayeeID *string `json:"headquarters_payee_id"`
+				Branches            []struct {
+					PayeeID      string `json:"payee_id"`
+					BranchOrder  string `json:"branch_order"`
+					Headquarters bool   `json:"headquarters"`
+				} `json:"branches"`
+			} `json:"data"`
+		}
+		require.NoError(t, json.NewDecoder(rec.Body).Decode(&response))
+
+		require.Len(t, response.Data, 2)
+
+		assert.Equal(t, "11222333", response.Data[0].CNPJRoot)
+		assert.Equal(t, &headquartersID, response.Data[0].HeadquartersPayeeID)
+		require.Len(t, response.Data[0].Branches, 2)
+		assert.Equal(t, headquartersID, response.Data[0].Branches[0].PayeeID)
+		assert.True(t, response.Data[0].Branches[0].Headquarters)
+		assert.Equal(t, branchID, response.Data[0].Branches[1].PayeeID)
+		assert.Equal(t, "0002", response.Data[0].Branches[1].BranchOrder)
+
+		assert.Equal(t, "19039318", response.Data[1].CNPJRoot)
+		assert.Equal(t, otherID, response.Data[1].Branches[0].PayeeID)
+	})
+
+	t.Run("given cnpj root filter should list payees of company", func(t *testing.T) {
+		list := listPayees(t, router, tenantID, "?cnpj_root=11.222.333")
+
+		require.Len(t, list.Data, 2)
+		assert.Equal(t, branchID, list.Data[0]["id"])
+		assert.Equal(t, headquartersID, list.Data[1]["id"])
+	})
+}
@@ -0,0 +1,116 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/italorfeitosa/payee-account-manager-api/internal/application"
+)
+
+// dataSubjectRequest carries cpf in body, so it is not logged as part of urls
+type dataSubjectRequest struct {
+	CPF string `json:"cpf"`
+}
+
+type auditEntryResponse struct {
+	Action     string    `json:"action"`
+	Actor      string    `json:"actor"`
+	OccurredAt time.Time `json:"occurred_at"`
+}
+
+type dataSubjectPayeeResponse struct {
+	payeeResponse
+	EmailVerifiedAt *time.Time           `json:"email_verified_at"`
+	DeletedAt       *time.Time           `json:"deleted_at"`
+	AnonymizedAt    *time.Time           `json:"anonymized_at"`
+	History         []auditEntryResponse `json:"history"`
+}
+
+type dataSubjectExportResponse struct {
+	CPF        string                     `json:"cpf"`
+	ExportedAt time.Time                  `json:"exported_at"`
+	Payees     []dataSubjectPayeeResponse `json:"payees"`
+}
+
+type dataSubjectAnonymizationResponse struct {
+	AnonymizedPayeeIDs []string `json:"anonymized_payee_ids"`
+}
+
+// DataSubjectHandler handles http requests of LGPD data subjects, identified by cpf
+type DataSubjectHandler struct {
+	exportDataSubject    *application.ExportDataSubjectUseCase
+	anonymizeDataSubject *application.AnonymizeDataSubjectUseCase
+}
+
+func NewDataSubjectHandler(
+	exportDataSubject *application.ExportDataSubjectUseCase,
+	anonymizeDataSubject *application.AnonymizeDataSubjectUseCase,
+) *DataSubjectHandler {
+	return &DataSubjectHandler{
+		exportDataSubject:    exportDataSubject,
+		anonymizeDataSubject: anonymizeDataSubject,
+	}
+}
+
+// Export handles POST api/v1/data-subjects/export, payees are returned unmasked with their history
+func (h *DataSubjectHandler) Export(w http.ResponseWriter, r *http.Request) {
+	var body dataSubjectRequest
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	output, err := h.exportDataSubject.Execute(r.Context(), tenantFromContext(r.Context()), body.CPF)
+	if err != nil {
+		writeUseCaseError(w, err)
+		return
+	}
+
+	response := dataSubjectExportResponse{
+		CPF:        output.CPF,
+		ExportedAt: output.ExportedAt,
+		Payees:     make([]dataSubjectPayeeResponse, 0, len(output.Payees)),
+	}
+
+	for _, payee := range output.Payees {
+		payeeResponse := dataSubjectPayeeResponse{
+			payeeResponse:   newPayeeResponse(payee.Payee, false),
+			EmailVerifiedAt: payee.Payee.EmailVerifiedAt(),
+			DeletedAt:       payee.DeletedAt,
+			AnonymizedAt:    payee.Payee.AnonymizedAt(),
+			History:         make([]auditEntryResponse, 0, len(payee.History)),
+		}
+
+		for _, entry := range payee.History {
+			payeeResponse.History = append(payeeResponse.History, auditEntryResponse{
+				Action:     string(entry.Action),
+				Actor:      entry.Actor,
+				OccurredAt: entry.OccurredAt,
+			})
+		}
+
+		response.Payees = append(response.Payees, payeeResponse)
+	}
+
+	// personal data must not be kept by proxies nor browsers
+	w.Header().Set("Cache-Control", "no-store")
+	writeJSON(w, http.StatusOK, dataResponse{Data: response})
+}
+
+// Anonymize handles POST api/v1/data-subjects/anonymize, it can't be undone
+func (h *DataSubjectHandler) Anonymize(w http.ResponseWriter, r *http.Request) {
+	var body dataSubjectRequest
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	output, err := h.anonymizeDataSubject.Execute(r.Context(), tenantFromContext(r.Context()), body.CPF)
+	if err != nil {
+		writeUseCaseError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, dataResponse{Data: dataSubjectAnonymizationResponse{AnonymizedPayeeIDs: output.PayeeIDs}})
+}
@@ -0,0 +1,153 @@
+package api_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// dataSubjectBody identifies the data subject of validPayeeBody
+const dataSubjectBody = `{"cpf": "998.180.830-08"}`
+
+type dataSubjectExportResponse struct {
+	Data struct {
+		CPF    string           `json:"cpf"`
+		Payees []map[string]any `json:"payees"`
+	} `json:"data"`
+}
+
+func exportDataSubject(t *testing.T, router http.Handler, tenantID string) dataSubjectExportResponse {
+	t.Helper()
+
+	rec := doRequest(router, http.MethodPost, "/api/v1/data-subjects/export", tenantID, dataSubjectBody)
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	assert.Equal(t, "no-store", rec.Header().Get("Cache-Control"))
+
+	var response dataSubjectExportResponse
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &response))
+
+	return response
+}
+
+func TestDataSubjectHandler_Export(t *testing.T) {
+	t.Run("given payees of cpf should export them unmasked with history including deleted ones", func(t *testing.T) {
+		router := newTestRouter()
+		tenantID := uuid.NewString()
+		deletedID := registerPayee(t, router, tenantID, validPayeeBody)
+		rec := doRequest(router, http.MethodPost, "/api/v1/payees/"+deletedID+"/validate", tenantID, validateBody)
+		require.Equal(t, http.StatusNoContent, rec.Code, rec.Body.String())
+		rec = doRequest(router, http.MethodDelete, "/api/v1/payees", tenantID, `{"ids": ["`+deletedID+`"]}`)
+		require.Equal(t, http.StatusNoContent, rec.Code, rec.Body.String())
+		activeID := registerPayee(t, router, tenantID, validPayeeBody)
+		registerPayee(t, router, uuid.NewString(), validPayeeBody)
+
+		response := exportDataSubject(t, router, tenantID)
+
+		assert.Equal(t, "99818083008", response.Data.CPF)
+		require.Len(t, response.Data.Payees, 2)
+
+		deleted := response.Data.Payees[0]
+		assert.Equal(t, deletedID, deleted["id"])
+		assert.Equal(t, "99818083008", deleted["cpf_cnpj"])
+		assert.Equal(t, "65465465", deleted["bank_account"].(map[string]any)["account_number"])
+		assert.NotNil(t, deleted["deleted_at"])
+		assert.Nil(t, deleted["anonymized_at"])
+
+		var actions []any
+		for _, entry := range deleted["history"].([]any) {
+			actions = append(actions, entry.(map[string]any)["action"])
+		}
+		assert.Equal(t, []any{"payee.registered", "payee.validated", "payee.deleted"}, actions)
+
+		active := response.Data.Payees[1]
+		assert.Equal(t, activeID, active["id"])
+		assert.Nil(t, active["deleted_at"])
+	})
+
+	t.Run("given an export should record it in history", func(t *testing.T) {
+		router := newTestRouter()
+		tenantID := uuid.NewString()
+		registerPayee(t, router, tenantID, validPayeeBody)
+
+		exportDataSubject(t, router, tenantID)
+		response := exportDataSubject(t, router, tenantID)
+
+		history := response.Data.Payees[0]["history"].([]any)
+		require.Len(t, history, 2)
+		assert.Equal(t, "data_subject.exported", history[1].(map[string]any)["action"])
+	})
+
+	t.Run("given an invalid cpf should return 422", func(t *testing.T) {
+		rec := doRequest(newTestRouter(), http.MethodPost, "/api/v1/data-subjects/export", uuid.NewString(), `{"cpf": "99818083009"}`)
+
+		assert.Equal(t, http.StatusUnprocessableEntity, rec.Code)
+	})
+
+	t.Run("given an invalid body should return 400", func(t *testing.T) {
+		rec := doRequest(newTestRouter(), http.MethodPost, "/api/v1/data-subjects/export", uuid.NewString(), `{`)
+
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+	})
+}
+
+func TestDataSubjectHandler_Anonymize(t *testing.T) {
+	t.Run("given payees of cpf should scrub personal data and keep id and status", func(t *testing.T) {
+		router := newTestRouter()
+		tenantID := uuid.NewString()
+		id := registerPayee(t, router, tenantID, validPayeeBody)
+		rec := doRequest(router, http.MethodPost, "/api/v1/payees/"+id+"/validate", tenantID, validateBody)
+		require.Equal(t, http.StatusNoContent, rec.Code, rec.Body.String())
+
+		rec = doRequest(router, http.MethodPost, "/api/v1/data-subjects/anonymize", tenantID, dataSubjectBody)
+		require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+		assert.JSONEq(t, `{"data": {"anonymized_payee_ids": ["`+id+`"]}}`, rec.Body.String())
+
+		list := listPayees(t, router, tenantID, "")
+		require.Len(t, list.Data, 1)
+		payee := list.Data[0]
+		assert.Equal(t, id, payee["id"])
+		assert.Equal(t, "VALID", payee["status"])
+		assert.Equal(t, "", payee["name"])
+		assert.Equal(t, "", payee["cpf_cnpj"])
+		assert.Equal(t, "ANONYMIZED", payee["document_type"])
+		assert.Equal(t, "", payee["email"])
+		assert.Equal(t, "", payee["pix_key"])
+		assert.Equal(t, "", payee["bank_account"].(map[string]any)["account_number"])
+
+		response := exportDataSubject(t, router, tenantID)
+		assert.Empty(t, response.Data.Payees)
+	})
+
+	t.Run("given an anonymized payee should reject edition", func(t *testing.T) {
+		router := newTestRouter()
+		tenantID := uuid.NewString()
+		id := registerPayee(t, router, tenantID, validPayeeBody)
+		rec := doRequest(router, http.MethodPost, "/api/v1/data-subjects/anonymize", tenantID, dataSubjectBody)
+		require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+
+		rec = doRequest(router, http.MethodPut, "/api/v1/payees/"+id, tenantID, validPayeeBody)
+
+		assert.Equal(t, http.StatusConflict, rec.Code)
+	})
+
+	t.Run("given the cpf of an anonymized payee should register it again", func(t *testing.T) {
+		router := newTestRouter()
+		tenantID := uuid.NewString()
+		registerPayee(t, router, tenantID, validPayeeBody)
+		rec := doRequest(router, http.MethodPost, "/api/v1/data-subjects/anonymize", tenantID, dataSubjectBody)
+		require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+
+		registerPayee(t, router, tenantID, validPayeeBody)
+	})
+
+	t.Run("given a cpf without payees should return no ids", func(t *testing.T) {
+		rec := doRequest(newTestRouter(), http.MethodPost, "/api/v1/data-subjects/anonymize", uuid.NewString(), dataSubjectBody)
+
+		require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+		assert.JSONEq(t, `{"data": {"anonymized_payee_ids": []}}`, rec.Body.String())
+	})
+}
@@ -0,0 +1,3 @@
+// api package exposes the payee use cases through a REST API, following the contracts
+// documented in README (api/v1)
+package api
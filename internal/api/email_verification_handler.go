@@ -0,0 +1,55 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/italorfeitosa/payee-account-manager-api/internal/application"
+)
+
+type confirmEmailRequest struct {
+	Token string `json:"token"`
+}
+
+// EmailVerificationHandler handles http requests to verify payee emails
+type EmailVerificationHandler struct {
+	sendEmailVerification *application.SendEmailVerificationUseCase
+	confirmEmail          *application.ConfirmEmailUseCase
+}
+
+func NewEmailVerificationHandler(
+	sendEmailVerification *application.SendEmailVerificationUseCase,
+	confirmEmail *application.ConfirmEmailUseCase,
+) *EmailVerificationHandler {
+	return &EmailVerificationHandler{
+		sendEmailVerification: sendEmailVerification,
+		confirmEmail:          confirmEmail,
+	}
+}
+
+// Send handles POST api/v1/payees/{payee_id}/email-verification
+func (h *EmailVerificationHandler) Send(w http.ResponseWriter, r *http.Request) {
+	err := h.sendEmailVerification.Execute(r.Context(), tenantFromContext(r.Context()), r.PathValue("payee_id"))
+	if err != nil {
+		writeUseCaseError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusAccepted)
+}
+
+// Confirm handles POST api/v1/email-verifications/confirm, tenant and payee are taken from token
+func (h *EmailVerificationHandler) Confirm(w http.ResponseWriter, r *http.Request) {
+	var body confirmEmailRequest
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	if err := h.confirmEmail.Execute(r.Context(), body.Token); err != nil {
+		writeUseCaseError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,92 @@
+package api_test
+
+import (
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/italorfeitosa/payee-account-manager-api/internal/infra/memory"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// tokenFromMessage extracts the verification token from the confirmation link of last sent email
+func tokenFromMessage(t *testing.T, mailer *memory.Mailer) string {
+	t.Helper()
+
+	messages := mailer.Messages()
+	require.NotEmpty(t, messages)
+
+	body := messages[len(messages)-1].Body
+	start := strings.Index(body, "https://")
+	require.GreaterOrEqual(t, start, 0)
+
+	link, err := url.Parse(strings.Fields(body[start:])[0])
+	require.NoError(t, err)
+
+	return link.Query().Get("token")
+}
+
+func TestEmailVerificationHandler(t *testing.T) {
+	t.Run("given a confirmed token should mark email as verified", func(t *testing.T) {
+		mailer := memory.NewMailer()
+		router := newTestRouterWith(nil, mailer)
+		tenantID := uuid.NewString()
+		id := registerPayee(t, router, tenantID, validPayeeBody)
+
+		rec := doRequest(router, http.MethodPost, "/api/v1/payees/"+id+"/email-verification", tenantID, "")
+		require.Equal(t, http.StatusAccepted, rec.Code, rec.Body.String())
+		assert.Equal(t, "italo@feitosa.com", mailer.Messages()[0].To)
+
+		rec = doRequest(router, http.MethodPost, "/api/v1/email-verifications/confirm", "", `{"token": "`+tokenFromMessage(t, mailer)+`"}`)
+		require.Equal(t, http.StatusNoContent, rec.Code, rec.Body.String())
+
+		list := listPayees(t, router, tenantID, "")
+		assert.Equal(t, true, list.Data[0]["email_verified"])
+	})
+
+	t.Run("given an email changed after verification should reset it and reject old token", func(t *testing.T) {
+		mailer := memory.NewMailer()
+		router := newTestRouterWith(nil, mailer)
+		tenantID := uuid.NewString()
+		id := registerPayee(t, router, tenantID, validPayeeBody)
+
+		doRequest(router, http.MethodPost, "/api/v1/payees/"+id+"/email-verification", tenantID, "")
+		token := tokenFromMessage(t, mailer)
+		doRequest(router, http.MethodPost, "/api/v1/email-verifications/confirm", "", `{"token": "`+token+`"}`)
+
+		body := strings.Replace(validPayeeBody, "italo@feitosa.com", "other@feitosa.com", 1)
+		rec := doRequest(router, http.MethodPut, "/api/v1/payees/"+id, tenantID, body)
+		require.Equal(t, http.StatusNoContent, rec.Code, rec.Body.String())
+
+		list := listPayees(t, router, tenantID, "")
+		assert.Equal(t, false, list.Data[0]["email_verified"])
+
+		rec = doRequest(router, http.MethodPost, "/api/v1/email-verifications/confirm", "", `{"token": "`+token+`"}`)
+		assert.Equal(t, http.StatusUnprocessableEntity, rec.Code)
+	})
+
+	t.Run("given a tampered token should return 422", func(t *testing.T) {
+		mailer := memory.NewMailer()
+		router := newTestRouterWith(nil, mailer)
+		tenantID := uuid.NewString()
+		id := registerPayee(t, router, tenantID, validPayeeBody)
+
+		doRequest(router, http.MethodPost, "/api/v1/payees/"+id+"/email-verification", tenantID, "")
+		token := tokenFromMessage(t, mailer)
+
+		rec := doRequest(router, http.MethodPost, "/api/v1/email-verifications/confirm", "", `{"token": "x`+token+`"}`)
+		assert.Equal(t, http.StatusUnprocessableEntity, rec.Code)
+	})
+
+	t.Run("given a payee without email should return 422", func(t *testing.T) {
+		router := newTestRouter()
+		tenantID := uuid.NewString()
+		id := registerPayee(t, router, tenantID, strings.Replace(validPayeeBody, `"email": "italo@feitosa.com",`, "", 1))
+
+		rec := doRequest(router, http.MethodPost, "/api/v1/payees/"+id+"/email-verification", tenantID, "")
+		assert.Equal(t, http.StatusUnprocessableEntity, rec.Code)
+	})
+}
@@ -0,0 +1,67 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"sync/atomic"
+	"time"
+)
+
+// readinessTimeout bounds each readiness check, so a hanging dependency fails fast
+const readinessTimeout = 2 * time.Second
+
+// ReadinessCheck reports if a dependency can serve requests
+type ReadinessCheck func(ctx context.Context) error
+
+type healthResponse struct {
+	Status string            `json:"status"`
+	Checks map[string]string `json:"checks,omitempty"`
+}
+
+// HealthHandler handles liveness and readiness probes
+type HealthHandler struct {
+	checks   map[string]ReadinessCheck
+	draining atomic.Bool
+}
+
+// NewHealthHandler returns the handler, checks are keyed by dependency name
+func NewHealthHandler(checks map[string]ReadinessCheck) *HealthHandler {
+	return &HealthHandler{checks: checks}
+}
+
+// Drain makes readiness fail, so load balancers stop routing requests while server shuts down
+func (h *HealthHandler) Drain() {
+	h.draining.Store(true)
+}
+
+// Live handles GET /healthz, it succeeds while process is running
+func (h *HealthHandler) Live(w http.ResponseWriter, _ *http.Request) {
+	writeJSON(w, http.StatusOK, healthResponse{Status: "ok"})
+}
+
+// Ready handles GET /readyz, it fails when draining or when any check fails
+func (h *HealthHandler) Ready(w http.ResponseWriter, r *http.Request) {
+	if h.draining.Load() {
+		writeJSON(w, http.StatusServiceUnavailable, healthResponse{Status: "draining"})
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), readinessTimeout)
+	defer cancel()
+
+	response := healthResponse{Status: "ok", Checks: make(map[string]string, len(h.checks))}
+	status := http.StatusOK
+
+	for name, check := range h.checks {
+		if err := check(ctx); err != nil {
+			response.Checks[name] = err.Error()
+			response.Status = "unavailable"
+			status = http.StatusServiceUnavailable
+			continue
+		}
+
+		response.Checks[name] = "ok"
+	}
+
+	writeJSON(w, status, response)
+}
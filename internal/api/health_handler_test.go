@@ -0,0 +1,68 @@
+package api_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/italorfeitosa/payee-account-manager-api/internal/api"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func doHealthRequest(health *api.HealthHandler, target string) (int, map[string]any) {
+	router := api.NewRouter(nil, nil, nil, nil, nil, health, api.NewMetrics(), nil, nil)
+
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, target, nil))
+
+	var body map[string]any
+	_ = json.Unmarshal(rec.Body.Bytes(), &body)
+
+	return rec.Code, body
+}
+
+func TestHealthHandler(t *testing.T) {
+	healthy := func(context.Context) error { return nil }
+	failing := func(context.Context) error { return errors.New("connection refused") }
+
+	t.Run("given running server should be live", func(t *testing.T) {
+		code, body := doHealthRequest(api.NewHealthHandler(nil), "/healthz")
+
+		assert.Equal(t, http.StatusOK, code)
+		assert.Equal(t, "ok", body["status"])
+	})
+
+	t.Run("given healthy checks should be ready", func(t *testing.T) {
+		code, body := doHealthRequest(api.NewHealthHandler(map[string]api.ReadinessCheck{"repository": healthy}), "/readyz")
+
+		assert.Equal(t, http.StatusOK, code)
+		assert.Equal(t, "ok", body["status"])
+		assert.Equal(t, map[string]any{"repository": "ok"}, body["checks"])
+	})
+
+	t.Run("given failing check should not be ready", func(t *testing.T) {
+		health := api.NewHealthHandler(map[string]api.ReadinessCheck{"repository": healthy, "smtp": failing})
+
+		code, body := doHealthRequest(health, "/readyz")
+
+		assert.Equal(t, http.StatusServiceUnavailable, code)
+		assert.Equal(t, "unavailable", body["status"])
+		assert.Equal(t, map[string]any{"repository": "ok", "smtp": "connection refused"}, body["checks"])
+	})
+
+	t.Run("given draining server should not be ready but still live", func(t *testing.T) {
+		health := api.NewHealthHandler(map[string]api.ReadinessCheck{"repository": healthy})
+		health.Drain()
+
+		code, body := doHealthRequest(health, "/readyz")
+		require.Equal(t, http.StatusServiceUnavailable, code)
+		assert.Equal(t, "draining", body["status"])
+
+		code, _ = doHealthRequest(health, "/healthz")
+		assert.Equal(t, http.StatusOK, code)
+	})
+}
@@ -0,0 +1,99 @@
+package api_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/italorfeitosa/payee-account-manager-api/internal/api"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func doIdempotentRequest(router http.Handler, tenantID, key, body string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/payees", strings.NewReader(body))
+	authorize(req, tenantID)
+	req.Header.Set(api.IdempotencyKeyHeader, key)
+
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+
+	return rec
+}
+
+func registeredID(t *testing.T, rec *httptest.ResponseRecorder) string {
+	t.Helper()
+
+	require.Equal(t, http.StatusCreated, rec.Code, rec.Body.String())
+
+	var response struct {
+		Data struct {
+			ID string `json:"id"`
+		} `json:"data"`
+	}
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &response))
+
+	return response.Data.ID
+}
+
+func TestPayeeHandler_RegisterIdempotency(t *testing.T) {
+	t.Run("given a retry with same key and body should replay registered payee", func(t *testing.T) {
+		router := newTestRouter()
+		tenantID := uuid.NewString()
+		key := uuid.NewString()
+
+		first := doIdempotentRequest(router, tenantID, key, validPayeeBody)
+		id := registeredID(t, first)
+		assert.Empty(t, first.Header().Get(api.IdempotentReplayedHeader))
+
+		replay := doIdempotentRequest(router, tenantID, key, validPayeeBody)
+		assert.Equal(t, id, registeredID(t, replay))
+		assert.Equal(t, "true", replay.Header().Get(api.IdempotentReplayedHeader))
+
+		list := listPayees(t, router, tenantID, "")
+		assert.Len(t, list.Data, 1)
+	})
+
+	t.Run("given a retry with same key and different body should return 422", func(t *testing.T) {
+		router := newTestRouter()
+		tenantID := uuid.NewString()
+		key := uuid.NewString()
+
+		registeredID(t, doIdempotentRequest(router, tenantID, key, validPayeeBody))
+
+		rec := doIdempotentRequest(router, tenantID, key, strings.Replace(validPayeeBody, "Italo Feitosa", "Italo Rodrigues", 1))
+
+		assert.Equal(t, http.StatusUnprocessableEntity, rec.Code)
+		assert.JSONEq(t, `{"error": "idempotency key was already used with a different request"}`, rec.Body.String())
+	})
+
+	t.Run("given a failed request should allow retry with same key", func(t *testing.T) {
+		router := newTestRouter()
+		tenantID := uuid.NewString()
+		key := uuid.NewString()
+
+		rec := doIdempotentRequest(router, tenantID, key, strings.Replace(validPayeeBody, `"italo@feitosa.com"`, `"invalid"`, 1))
+		require.Equal(t, http.StatusUnprocessableEntity, rec.Code)
+
+		registeredID(t, doIdempotentRequest(router, tenantID, key, validPayeeBody))
+	})
+
+	t.Run("given same key in another tenant should register independently", func(t *testing.T) {
+		router := newTestRouter()
+		key := uuid.NewString()
+
+		first := registeredID(t, doIdempotentRequest(router, uuid.NewString(), key, validPayeeBody))
+		second := registeredID(t, doIdempotentRequest(router, uuid.NewString(), key, validPayeeBody))
+
+		assert.NotEqual(t, first, second)
+	})
+
+	t.Run("given a too long key should return 400", func(t *testing.T) {
+		rec := doIdempotentRequest(newTestRouter(), uuid.NewString(), strings.Repeat("k", 256), validPayeeBody)
+
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+	})
+}
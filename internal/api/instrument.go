@@ -0,0 +1,112 @@
+package api
+
+import (
+	"log/slog"
+	"net/http"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/italorfeitosa/payee-account-manager-api/internal/infra/logging"
+)
+
+// RequestIDHeader carries the correlation id of a request, it is generated when absent
+// and always echoed in response
+const RequestIDHeader = "X-Request-ID"
+
+// requestIDRegex limits ids accepted from clients, so they are safe to log
+var requestIDRegex = regexp.MustCompile(`^[A-Za-z0-9._-]{1,128}$`)
+
+// responseRecorder captures status code and error of a response
+type responseRecorder struct {
+	http.ResponseWriter
+	status          int
+	validationError string
+	err             error
+}
+
+func (r *responseRecorder) WriteHeader(status int) {
+	if r.status == 0 {
+		r.status = status
+	}
+
+	r.ResponseWriter.WriteHeader(status)
+}
+
+func (r *responseRecorder) Write(b []byte) (int, error) {
+	if r.status == 0 {
+		r.status = http.StatusOK
+	}
+
+	return r.ResponseWriter.Write(b)
+}
+
+// recordValidationError labels the response with the domain error name when it is instrumented
+func recordValidationError(w http.ResponseWriter, name string) {
+	if recorder, ok := w.(*responseRecorder); ok {
+		recorder.validationError = name
+	}
+}
+
+// recordUnexpectedError attaches err to the request log line, or logs it when response is not instrumented
+func recordUnexpectedError(w http.ResponseWriter, err error) {
+	if recorder, ok := w.(*responseRecorder); ok {
+		recorder.err = err
+		return
+	}
+
+	slog.Error("unexpected error", slog.String("error", err.Error()))
+}
+
+// instrument traces, measures and logs requests of pattern ("METHOD /path"),
+// every log line written with request context carries its request id
+func instrument(pattern string, metrics *Metrics, next http.Handler) http.Handler {
+	method, route, _ := strings.Cut(pattern, " ")
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		start := time.Now()
+
+		requestID := r.Header.Get(RequestIDHeader)
+		if !requestIDRegex.MatchString(requestID) {
+			requestID = uuid.NewString()
+		}
+		w.Header().Set(RequestIDHeader, requestID)
+
+		ctx := logging.WithRequestID(r.Context(), requestID)
+		ctx, span := startRequestSpan(ctx, r, pattern, method, route)
+
+		recorder := &responseRecorder{ResponseWriter: w}
+		next.ServeHTTP(recorder, r.WithContext(ctx))
+
+		if recorder.status == 0 {
+			recorder.status = http.StatusOK
+		}
+
+		duration := time.Since(start)
+
+		endRequestSpan(span, recorder.status)
+		metrics.observe(method, route, recorder.status, duration, recorder.validationError)
+
+		attrs := []slog.Attr{
+			slog.String("method", method),
+			slog.String("route", route),
+			slog.String("path", r.URL.Path),
+			slog.Int("status", recorder.status),
+			slog.Duration("duration", duration),
+		}
+
+		// path is redacted as ids look like random pix keys, payee_id attribute keeps it traceable
+		if payeeID := r.PathValue("payee_id"); payeeID != "" {
+			attrs = append(attrs, slog.String("payee_id", payeeID))
+		}
+
+		if recorder.err != nil {
+			attrs = append(attrs, slog.String("error", recorder.err.Error()))
+			slog.LogAttrs(ctx, slog.LevelError, "request failed", attrs...)
+			return
+		}
+
+		slog.LogAttrs(ctx, slog.LevelInfo, "request completed", attrs...)
+	})
+}
@@ -0,0 +1,47 @@
+package api_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/italorfeitosa/payee-account-manager-api/internal/api"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRequestID(t *testing.T) {
+	router := newTestRouter()
+
+	tests := []struct {
+		name      string
+		requestID string
+		wantEcho  bool
+	}{
+		{"given request id header should echo it", "0b7c1a9e-request.1", true},
+		{"given no request id header should generate one", "", false},
+		{"given unsafe request id header should replace it", "id\nwith-newline", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/api/v1/payees", nil)
+			authorize(req, uuid.NewString())
+			if tt.requestID != "" {
+				req.Header.Set(api.RequestIDHeader, tt.requestID)
+			}
+
+			rec := httptest.NewRecorder()
+			router.ServeHTTP(rec, req)
+
+			got := rec.Header().Get(api.RequestIDHeader)
+			if tt.wantEcho {
+				assert.Equal(t, tt.requestID, got)
+				return
+			}
+
+			_, err := uuid.Parse(got)
+			assert.NoError(t, err)
+		})
+	}
+}
@@ -0,0 +1,97 @@
+package api
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// Metrics holds the prometheus collectors of http traffic and payee business events
+type Metrics struct {
+	registry           *prometheus.Registry
+	requests           *prometheus.CounterVec
+	requestDuration    *prometheus.HistogramVec
+	payeesRegistered   *prometheus.CounterVec
+	payeesValidated    *prometheus.CounterVec
+	payeesDeleted      *prometheus.CounterVec
+	validationFailures *prometheus.CounterVec
+}
+
+// NewMetrics registers the collectors, with go runtime and process ones, in a new registry
+func NewMetrics() *Metrics {
+	m := &Metrics{
+		registry: prometheus.NewRegistry(),
+		requests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "http_requests_total",
+			Help: "Number of http requests by method, route and status code.",
+		}, []string{"method", "route", "status"}),
+		requestDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Name:    "http_request_duration_seconds",
+			Help:    "Latency of http requests by method, route and status code.",
+			Buckets: prometheus.DefBuckets,
+		}, []string{"method", "route", "status"}),
+		payeesRegistered: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "payees_registered_total",
+			Help: "Number of payees registered by tenant.",
+		}, []string{"tenant"}),
+		payeesValidated: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "payees_validated_total",
+			Help: "Number of payees validated by tenant.",
+		}, []string{"tenant"}),
+		payeesDeleted: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "payees_deleted_total",
+			Help: "Number of payees deleted by tenant.",
+		}, []string{"tenant"}),
+		validationFailures: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "payee_validation_failures_total",
+			Help: "Number of requests rejected by invalid input, by domain error.",
+		}, []string{"error"}),
+	}
+
+	m.registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		m.requests,
+		m.requestDuration,
+		m.payeesRegistered,
+		m.payeesValidated,
+		m.payeesDeleted,
+		m.validationFailures,
+	)
+
+	return m
+}
+
+// Handler handles GET /metrics in prometheus text format
+func (m *Metrics) Handler() http.Handler {
+	return promhttp.HandlerFor(m.registry, promhttp.HandlerOpts{})
+}
+
+// observe counts and times a request, route is the pattern path so payee ids
+// don't create a time series per payee
+func (m *Metrics) observe(method, route string, status int, duration time.Duration, validationError string) {
+	code := strconv.Itoa(status)
+
+	m.requests.WithLabelValues(method, route, code).Inc()
+	m.requestDuration.WithLabelValues(method, route, code).Observe(duration.Seconds())
+
+	if validationError != "" {
+		m.validationFailures.WithLabelValues(validationError).Inc()
+	}
+}
+
+func (m *Metrics) recordPayeeRegistered(tenantID string) {
+	m.payeesRegistered.WithLabelValues(tenantID).Inc()
+}
+
+func (m *Metrics) recordPayeeValidated(tenantID string) {
+	m.payeesValidated.WithLabelValues(tenantID).Inc()
+}
+
+func (m *Metrics) recordPayeesDeleted(tenantID string, count int) {
+	m.payeesDeleted.WithLabelValues(tenantID).Add(float64(count))
+}
@@ -0,0 +1,72 @@
+package api_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/italorfeitosa/payee-account-manager-api/internal/api"
+	"github.com/italorfeitosa/payee-account-manager-api/internal/infra/memory"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func scrapeMetrics(t *testing.T, metrics *api.Metrics) string {
+	t.Helper()
+
+	rec := httptest.NewRecorder()
+	metrics.Handler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	require.Equal(t, http.StatusOK, rec.Code)
+
+	return rec.Body.String()
+}
+
+func TestMetrics(t *testing.T) {
+	metrics := api.NewMetrics()
+	router := newInstrumentedTestRouter(nil, memory.NewMailer(), metrics)
+	tenantID := "6f1c2a4e-8b5d-4c3a-9e7f-1a2b3c4d5e6f"
+
+	id := registerPayee(t, router, tenantID, validPayeeBody)
+
+	rec := doRequest(router, http.MethodPost, "/api/v1/payees", tenantID, strings.Replace(validPayeeBody, `"pix_key": "99818083008"`, `"pix_key": "99818083009"`, 1))
+	require.Equal(t, http.StatusUnprocessableEntity, rec.Code)
+
+	// unknown ids are not counted as deleted payees
+	rec = doRequest(router, http.MethodDelete, "/api/v1/payees", tenantID, `{"ids": ["`+id+`", "`+uuid.NewString()+`"]}`)
+	require.Equal(t, http.StatusNoContent, rec.Code)
+
+	body := scrapeMetrics(t, metrics)
+
+	t.Run("given requests should count them by method, route and status", func(t *testing.T) {
+		assert.Contains(t, body, `http_requests_total{method="POST",route="/api/v1/payees",status="201"} 1`)
+		assert.Contains(t, body, `http_requests_total{method="POST",route="/api/v1/payees",status="422"} 1`)
+		assert.Contains(t, body, `http_requests_total{method="DELETE",route="/api/v1/payees",status="204"} 1`)
+		assert.Contains(t, body, `http_request_duration_seconds_count{method="POST",route="/api/v1/payees",status="201"} 1`)
+	})
+
+	t.Run("given payee events should count them by tenant", func(t *testing.T) {
+		assert.Contains(t, body, `payees_registered_total{tenant="6f1c2a4e-8b5d-4c3a-9e7f-1a2b3c4d5e6f"} 1`)
+		assert.Contains(t, body, `payees_deleted_total{tenant="6f1c2a4e-8b5d-4c3a-9e7f-1a2b3c4d5e6f"} 1`)
+	})
+
+	t.Run("given invalid input should count failure by domain error", func(t *testing.T) {
+		assert.Contains(t, body, `payee_validation_failures_total{error="ErrInvalidCPF"} 1`)
+	})
+
+	t.Run("given path with payee id should label route with pattern", func(t *testing.T) {
+		doRequest(router, http.MethodPut, "/api/v1/payees/"+id, tenantID, validPayeeBody)
+
+		assert.Contains(t, scrapeMetrics(t, metrics), `route="/api/v1/payees/{payee_id}"`)
+		assert.NotContains(t, scrapeMetrics(t, metrics), id)
+	})
+
+	t.Run("given api router should not expose metrics", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+
+		assert.Equal(t, http.StatusNotFound, rec.Code)
+		assert.NotContains(t, rec.Body.String(), tenantID)
+	})
+}
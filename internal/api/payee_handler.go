@@ -0,0 +1,257 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/italorfeitosa/payee-account-manager-api/internal/application"
+	"github.com/italorfeitosa/payee-account-manager-api/internal/domain"
+)
+
+type payeeRequest struct {
+	Name              string                     `json:"name"`
+	TradeName         string                     `json:"trade_name"`
+	InscricaoEstadual *inscricaoEstadualResponse `json:"inscricao_estadual"`
+	Document          string                     `json:"cpf_cnpj"`
+	Email             string                     `json:"email"`
+	PixKeyType        string                     `json:"pix_key_type"`
+	PixKey            string                     `json:"pix_key"`
+	Address           *addressResponse           `json:"address"`
+	Contacts          []contactResponse          `json:"contacts"`
+}
+
+// details maps optional details of body to use case inputs
+func (b payeeRequest) details() (*application.InscricaoEstadualInput, *application.AddressInput, []application.ContactInput) {
+	var inscricaoEstadual *application.InscricaoEstadualInput
+	if b.InscricaoEstadual != nil {
+		inscricaoEstadual = &application.InscricaoEstadualInput{UF: b.InscricaoEstadual.UF, Value: b.InscricaoEstadual.Value}
+	}
+
+	var address *application.AddressInput
+	if b.Address != nil {
+		address = &application.AddressInput{
+			Street:       b.Address.Street,
+			Number:       b.Address.Number,
+			Complement:   b.Address.Complement,
+			Neighborhood: b.Address.Neighborhood,
+			City:         b.Address.City,
+			UF:           b.Address.UF,
+			CEP:          b.Address.CEP,
+		}
+	}
+
+	contacts := make([]application.ContactInput, 0, len(b.Contacts))
+	for _, contact := range b.Contacts {
+		contacts = append(contacts, application.ContactInput{
+			Type:    contact.Type,
+			Value:   contact.Value,
+			Primary: contact.Primary,
+		})
+	}
+
+	return inscricaoEstadual, address, contacts
+}
+
+type deletePayeesRequest struct {
+	IDs []string `json:"ids"`
+}
+
+type validatePayeeRequest struct {
+	BankAccount bankAccountResponse `json:"bank_account"`
+}
+
+type registerPayeeResponse struct {
+	ID string `json:"id"`
+}
+
+// PayeeHandler handles payee http requests, delegating to use cases
+type PayeeHandler struct {
+	registerPayee *application.RegisterPayeeUseCase
+	editPayee     *application.EditPayeeUseCase
+	listPayees    *application.ListPayeesUseCase
+	deletePayees  *application.DeletePayeesUseCase
+	validatePayee *application.ValidatePayeeUseCase
+	metrics       *Metrics
+}
+
+func NewPayeeHandler(
+	registerPayee *application.RegisterPayeeUseCase,
+	editPayee *application.EditPayeeUseCase,
+	listPayees *application.ListPayeesUseCase,
+	deletePayees *application.DeletePayeesUseCase,
+	validatePayee *application.ValidatePayeeUseCase,
+	metrics *Metrics,
+) *PayeeHandler {
+	return &PayeeHandler{
+		registerPayee: registerPayee,
+		editPayee:     editPayee,
+		listPayees:    listPayees,
+		deletePayees:  deletePayees,
+		validatePayee: validatePayee,
+		metrics:       metrics,
+	}
+}
+
+// IdempotencyKeyHeader makes registration safe to retry, see application.RegisterPayeeInput
+const IdempotencyKeyHeader = "Idempotency-Key"
+
+// IdempotentReplayedHeader is set when registration response is replayed from a previous request
+const IdempotentReplayedHeader = "Idempotent-Replayed"
+
+// Register handles POST api/v1/payees
+func (h *PayeeHandler) Register(w http.ResponseWriter, r *http.Request) {
+	var body payeeRequest
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	inscricaoEstadual, address, contacts := body.details()
+
+	tenantID := tenantFromContext(r.Context())
+
+	output, err := h.registerPayee.Execute(r.Context(), tenantID, application.RegisterPayeeInput{
+		Name:              body.Name,
+		TradeName:         body.TradeName,
+		InscricaoEstadual: inscricaoEstadual,
+		Document:          body.Document,
+		Email:             body.Email,
+		PixKeyType:        body.PixKeyType,
+		PixKey:            body.PixKey,
+		Address:           address,
+		Contacts:          contacts,
+		IdempotencyKey:    r.Header.Get(IdempotencyKeyHeader),
+	})
+	if err != nil {
+		writeUseCaseError(w, err)
+		return
+	}
+
+	if output.Replayed {
+		w.Header().Set(IdempotentReplayedHeader, "true")
+	} else {
+		h.metrics.recordPayeeRegistered(tenantID)
+	}
+
+	writeJSON(w, http.StatusCreated, dataResponse{Data: registerPayeeResponse{ID: output.ID}})
+}
+
+// Edit handles PUT api/v1/payees/{payee_id}
+func (h *PayeeHandler) Edit(w http.ResponseWriter, r *http.Request) {
+	var body payeeRequest
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	inscricaoEstadual, address, contacts := body.details()
+
+	err := h.editPayee.Execute(r.Context(), tenantFromContext(r.Context()), r.PathValue("payee_id"), application.EditPayeeInput{
+		Name:              body.Name,
+		TradeName:         body.TradeName,
+		InscricaoEstadual: inscricaoEstadual,
+		Document:          body.Document,
+		Email:             body.Email,
+		PixKeyType:        body.PixKeyType,
+		PixKey:            body.PixKey,
+		Address:           address,
+		Contacts:          contacts,
+	})
+	if err != nil {
+		writeUseCaseError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// List handles GET api/v1/payees?page=1&size=10&search=&person_kind=&cnpj_root=&masked=false
+// when masked is true, cpf_cnpj, email, contacts, pix keys and bank account number are returned masked,
+// actors without permission to view sensitive data, like viewers, always get them masked
+func (h *PayeeHandler) List(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+
+	page, _ := strconv.Atoi(query.Get("page"))
+	size, _ := strconv.Atoi(query.Get("size"))
+	masked, _ := strconv.ParseBool(query.Get("masked"))
+
+	output, err := h.listPayees.Execute(r.Context(), tenantFromContext(r.Context()), application.ListPayeesInput{
+		Page:       page,
+		Size:       size,
+		Search:     query.Get("search"),
+		PersonKind: query.Get("person_kind"),
+		CNPJRoot:   query.Get("cnpj_root"),
+		Masked:     masked,
+	})
+	if err != nil {
+		writeUseCaseError(w, err)
+		return
+	}
+
+	payees := make([]payeeResponse, 0, len(output.Payees))
+	for _, payee := range output.Payees {
+		payees = append(payees, newPayeeResponse(payee, output.Masked))
+	}
+
+	writeJSON(w, http.StatusOK, dataResponse{
+		Data: payees,
+		Metadata: pageMetadata{
+			TotalItems: output.TotalItems,
+			TotalPages: output.TotalPages,
+			Page:       output.Page,
+			PageSize:   output.PageSize,
+		},
+	})
+}
+
+// Delete handles DELETE api/v1/payees
+func (h *PayeeHandler) Delete(w http.ResponseWriter, r *http.Request) {
+	var body deletePayeesRequest
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	tenantID := tenantFromContext(r.Context())
+
+	deleted, err := h.deletePayees.Execute(r.Context(), tenantID, body.IDs)
+	if err != nil {
+		writeUseCaseError(w, err)
+		return
+	}
+
+	h.metrics.recordPayeesDeleted(tenantID, len(deleted))
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// Validate handles POST api/v1/payees/{payee_id}/validate
+func (h *PayeeHandler) Validate(w http.ResponseWriter, r *http.Request) {
+	var body validatePayeeRequest
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	tenantID := tenantFromContext(r.Context())
+
+	err := h.validatePayee.Execute(r.Context(), tenantID, r.PathValue("payee_id"), application.ValidatePayeeInput{
+		BankAccount: domain.BankAccount{
+			AccountType:   body.BankAccount.AccountType,
+			AccountNumber: body.BankAccount.AccountNumber,
+			AccountDigit:  body.BankAccount.AccountDigit,
+			BranchNumber:  body.BankAccount.BranchNumber,
+			BankCode:      body.BankAccount.BankCode,
+			BankIspb:      body.BankAccount.BankIspb,
+		},
+	})
+	if err != nil {
+		writeUseCaseError(w, err)
+		return
+	}
+
+	h.metrics.recordPayeeValidated(tenantID)
+
+	w.WriteHeader(http.StatusNoContent)
+}
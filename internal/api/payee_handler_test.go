@@ -0,0 +1,435 @@
+package api_test
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/italorfeitosa/payee-account-manager-api/internal/api"
+	"github.com/italorfeitosa/payee-account-manager-api/internal/application"
+	"github.com/italorfeitosa/payee-account-manager-api/internal/domain"
+	"github.com/italorfeitosa/payee-account-manager-api/internal/infra/encryption"
+	"github.com/italorfeitosa/payee-account-manager-api/internal/infra/memory"
+	"github.com/italorfeitosa/payee-account-manager-api/internal/infra/tracing"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestRouter() http.Handler {
+	return newTestRouterWith(nil, memory.NewMailer())
+}
+
+// testRateLimits are high enough to never limit handler tests
+var testRateLimits = api.RateLimits{ReadsPerSecond: 1000, ReadBurst: 1000, WritesPerSecond: 1000, WriteBurst: 1000}
+
+func newTestRouterWith(checker application.DocumentStatusChecker, mailer application.Mailer) http.Handler {
+	return newInstrumentedTestRouter(checker, mailer, api.NewMetrics())
+}
+
+func newInstrumentedTestRouter(checker application.DocumentStatusChecker, mailer application.Mailer, metrics *api.Metrics) http.Handler {
+	return newConfiguredTestRouter(checker, mailer, metrics, func(string) api.RateLimits { return testRateLimits }, nil, memory.NewAuditLog())
+}
+
+// newTestFieldEncrypter encrypts payee fields of test repositories with random keys
+func newTestFieldEncrypter() *encryption.FieldEncrypter {
+	key, indexKey := make([]byte, encryption.KeySize), make([]byte, encryption.KeySize)
+	_, _ = rand.Read(key)
+	_, _ = rand.Read(indexKey)
+
+	ring, err := encryption.NewKeyRing("test", map[string][]byte{"test": key}, indexKey)
+	if err != nil {
+		panic(err)
+	}
+
+	return encryption.NewFieldEncrypter(ring)
+}
+
+func newConfiguredTestRouter(
+	checker application.DocumentStatusChecker,
+	mailer application.Mailer,
+	metrics *api.Metrics,
+	limits func(tenantID string) api.RateLimits,
+	quota application.RegistrationQuota,
+	audit application.AuditLog,
+) http.Handler {
+	store := memory.NewPayeeRepository(newTestFieldEncrypter())
+	repository := tracing.NewPayeeRepository(store)
+	tokens := application.NewEmailVerificationTokens([]byte("test-secret"), application.DefaultEmailVerificationTTL)
+
+	return api.NewRouter(
+		api.NewPayeeHandler(
+			application.NewRegisterPayeeUseCase(repository, quota, memory.NewIdempotencyStore(time.Hour, time.Minute), audit),
+			application.NewEditPayeeUseCase(repository, audit),
+			application.NewListPayeesUseCase(repository),
+			application.NewDeletePayeesUseCase(repository, audit),
+			application.NewValidatePayeeUseCase(repository, checker, audit),
+			metrics,
+		),
+		api.NewPixKeyHandler(
+			application.NewAddPixKeyUseCase(repository, audit),
+			application.NewRemovePixKeyUseCase(repository, audit),
+			application.NewSetPrimaryPixKeyUseCase(repository, audit),
+		),
+		api.NewCompanyHandler(application.NewGroupPayeesByCompanyUseCase(repository)),
+		api.NewEmailVerificationHandler(
+			application.NewSendEmailVerificationUseCase(repository, mailer, tokens, audit, "https://payees.com/confirm-email"),
+			application.NewConfirmEmailUseCase(repository, tokens, audit),
+		),
+		api.NewDataSubjectHandler(
+			application.NewExportDataSubjectUseCase(repository, audit),
+			application.NewAnonymizeDataSubjectUseCase(repository, audit),
+		),
+		api.NewHealthHandler(map[string]api.ReadinessCheck{"repository": store.Ping}),
+		metrics,
+		api.NewRateLimiter(limits),
+		api.NewAuthenticator(testKeyfunc, testIssuer, testAudience),
+	)
+}
+
+func doRequest(router http.Handler, method, target, tenantID, body string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, target, strings.NewReader(body))
+	if tenantID != "" {
+		authorize(req, tenantID)
+	}
+
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+
+	return rec
+}
+
+const validPayeeBody = `{
+	"name": "Italo Feitosa",
+	"cpf_cnpj": "99818083008",
+	"email": "italo@feitosa.com",
+	"pix_key_type": "CPF",
+	"pix_key": "99818083008"
+}`
+
+type listResponse struct {
+	Data []map[string]any `json:"data"`
+	Meta map[string]int   `json:"metadata"`
+}
+
+func registerPayee(t *testing.T, router http.Handler, tenantID, body string) string {
+	t.Helper()
+
+	rec := doRequest(router, http.MethodPost, "/api/v1/payees", tenantID, body)
+	require.Equal(t, http.StatusCreated, rec.Code, rec.Body.String())
+
+	var response struct {
+		Data struct {
+			ID string `json:"id"`
+		} `json:"data"`
+	}
+	require.NoError(t, json.NewDecoder(rec.Body).Decode(&response))
+
+	return response.Data.ID
+}
+
+func listPayees(t *testing.T, router http.Handler, tenantID, query string) listResponse {
+	t.Helper()
+
+	rec := doRequest(router, http.MethodGet, "/api/v1/payees"+query, tenantID, "")
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+
+	var response listResponse
+	require.NoError(t, json.NewDecoder(rec.Body).Decode(&response))
+
+	return response
+}
+
+func TestPayeeHandler_Register(t *testing.T) {
+	router := newTestRouter()
+
+	t.Run("given a valid body should return 201 with id", func(t *testing.T) {
+		id := registerPayee(t, router, uuid.NewString(), validPayeeBody)
+
+		assert.NotEmpty(t, id)
+	})
+
+	t.Run("given an invalid document should return 422", func(t *testing.T) {
+		body := strings.Replace(validPayeeBody, `"cpf_cnpj": "99818083008"`, `"cpf_cnpj": "123"`, 1)
+
+		rec := doRequest(router, http.MethodPost, "/api/v1/payees", uuid.NewString(), body)
+
+		assert.Equal(t, http.StatusUnprocessableEntity, rec.Code)
+	})
+
+	t.Run("given an omitted pix key type should detect it from pix key", func(t *testing.T) {
+		tenantID := uuid.NewString()
+		body := strings.Replace(validPayeeBody, `"pix_key_type": "CPF",`, "", 1)
+
+		registerPayee(t, router, tenantID, body)
+
+		list := listPayees(t, router, tenantID, "")
+		require.Len(t, list.Data, 1)
+		assert.Equal(t, "CPF", list.Data[0]["pix_key_type"])
+	})
+
+	t.Run("given an omitted pix key type when pix key is ambiguous should return 422", func(t *testing.T) {
+		body := `{"name": "Italo Feitosa", "cpf_cnpj": "99818083008", "pix_key": "11987654374"}`
+
+		rec := doRequest(router, http.MethodPost, "/api/v1/payees", uuid.NewString(), body)
+
+		assert.Equal(t, http.StatusUnprocessableEntity, rec.Code)
+	})
+
+	t.Run("given a CNPJ with trade name should return normalized legal name and trade name", func(t *testing.T) {
+		tenantID := uuid.NewString()
+		body := `{"name": "Nu Pagamentos s/a", "trade_name": "Nubank", "cpf_cnpj": "19039318000104", "pix_key_type": "CNPJ", "pix_key": "19039318000104"}`
+
+		registerPayee(t, router, tenantID, body)
+
+		list := listPayees(t, router, tenantID, "?search=nubank")
+		require.Len(t, list.Data, 1)
+		assert.Equal(t, "Nu Pagamentos S.A.", list.Data[0]["name"])
+		assert.Equal(t, "Nubank", list.Data[0]["trade_name"])
+	})
+
+	t.Run("given address and contacts should return them formatted", func(t *testing.T) {
+		tenantID := uuid.NewString()
+		body := strings.Replace(validPayeeBody, `"name": "Italo Feitosa",`, `"name": "Italo Feitosa",
+			"address": {"street": "Avenida Paulista", "number": "1578", "neighborhood": "Bela Vista", "city": "São Paulo", "uf": "SP", "cep": "01310200"},
+			"contacts": [{"type": "PHONE", "value": "(11) 3123-4567", "primary": true}, {"type": "EMAIL", "value": "italo@feitosa.com"}],`, 1)
+
+		registerPayee(t, router, tenantID, body)
+
+		list := listPayees(t, router, tenantID, "")
+		require.Len(t, list.Data, 1)
+		address := list.Data[0]["address"].(map[string]any)
+		assert.Equal(t, "01310-200", address["cep"])
+		assert.Equal(t, "SP", address["uf"])
+		contacts := list.Data[0]["contacts"].([]any)
+		require.Len(t, contacts, 2)
+		assert.Equal(t, "551131234567", contacts[0].(map[string]any)["value"])
+		assert.Equal(t, true, contacts[0].(map[string]any)["primary"])
+	})
+
+	t.Run("given a cep of another uf should return 422", func(t *testing.T) {
+		body := strings.Replace(validPayeeBody, `"name": "Italo Feitosa",`, `"name": "Italo Feitosa",
+			"address": {"street": "Avenida Paulista", "number": "1578", "neighborhood": "Bela Vista", "city": "São Paulo", "uf": "RJ", "cep": "01310200"},`, 1)
+
+		rec := doRequest(router, http.MethodPost, "/api/v1/payees", uuid.NewString(), body)
+
+		assert.Equal(t, http.StatusUnprocessableEntity, rec.Code)
+	})
+
+	t.Run("given a CNPJ with inscricao estadual should be searchable by it", func(t *testing.T) {
+		tenantID := uuid.NewString()
+		body := `{"name": "Fake Company", "inscricao_estadual": {"uf": "SP", "value": "110.042.490.114"}, "cpf_cnpj": "19039318000104", "pix_key_type": "CNPJ", "pix_key": "19039318000104"}`
+
+		registerPayee(t, router, tenantID, body)
+
+		for _, search := range []string{"110.042.490.114", "110042490114", "110%20042%20490%20114"} {
+			list := listPayees(t, router, tenantID, "?search="+search)
+			require.Len(t, list.Data, 1, search)
+			assert.Equal(t, map[string]any{"uf": "SP", "value": "110042490114"}, list.Data[0]["inscricao_estadual"])
+		}
+	})
+
+	t.Run("given an invalid inscricao estadual should return 422", func(t *testing.T) {
+		body := `{"name": "Fake Company", "inscricao_estadual": {"uf": "SP", "value": "110.042.490.115"}, "cpf_cnpj": "19039318000104", "pix_key_type": "CNPJ", "pix_key": "19039318000104"}`
+
+		rec := doRequest(router, http.MethodPost, "/api/v1/payees", uuid.NewString(), body)
+
+		assert.Equal(t, http.StatusUnprocessableEntity, rec.Code)
+	})
+
+	t.Run("given a CPF with trade name should return 422", func(t *testing.T) {
+		body := strings.Replace(validPayeeBody, `"name": "Italo Feitosa",`, `"name": "Italo Feitosa", "trade_name": "Italo",`, 1)
+
+		rec := doRequest(router, http.MethodPost, "/api/v1/payees", uuid.NewString(), body)
+
+		assert.Equal(t, http.StatusUnprocessableEntity, rec.Code)
+	})
+
+	t.Run("given a document already registered in tenant should return 409 with existing payee id", func(t *testing.T) {
+		tenantID := uuid.NewString()
+		existingID := registerPayee(t, router, tenantID, validPayeeBody)
+
+		rec := doRequest(router, http.MethodPost, "/api/v1/payees", tenantID, validPayeeBody)
+		require.Equal(t, http.StatusConflict, rec.Code)
+
+		var response map[string]string
+		require.NoError(t, json.NewDecoder(rec.Body).Decode(&response))
+		assert.Equal(t, "cpf_cnpj", response["field"])
+		assert.Equal(t, existingID, response["existing_payee_id"])
+	})
+}
+
+func TestPayeeHandler_Edit(t *testing.T) {
+	router := newTestRouter()
+	tenantID := uuid.NewString()
+	id := registerPayee(t, router, tenantID, validPayeeBody)
+
+	t.Run("given a draft payee should return 204 and update values", func(t *testing.T) {
+		body := strings.Replace(validPayeeBody, "Italo Feitosa", "Italo Rodrigues", 1)
+
+		rec := doRequest(router, http.MethodPut, "/api/v1/payees/"+id, tenantID, body)
+		require.Equal(t, http.StatusNoContent, rec.Code)
+
+		list := listPayees(t, router, tenantID, "")
+		assert.Equal(t, "Italo Rodrigues", list.Data[0]["name"])
+	})
+
+	t.Run("given an unknown payee should return 404", func(t *testing.T) {
+		rec := doRequest(router, http.MethodPut, "/api/v1/payees/"+uuid.NewString(), tenantID, validPayeeBody)
+
+		assert.Equal(t, http.StatusNotFound, rec.Code)
+	})
+}
+
+func TestPayeeHandler_List(t *testing.T) {
+	router := newTestRouter()
+	tenantID := uuid.NewString()
+	registerPayee(t, router, tenantID, validPayeeBody)
+
+	t.Run("given no options should return paginated payees with full values", func(t *testing.T) {
+		list := listPayees(t, router, tenantID, "")
+
+		require.Len(t, list.Data, 1)
+		assert.Equal(t, "99818083008", list.Data[0]["cpf_cnpj"])
+		assert.Equal(t, "99818083008", list.Data[0]["pix_key"])
+		assert.Equal(t, "italo@feitosa.com", list.Data[0]["email"])
+		assert.Equal(t, "DRAFT", list.Data[0]["status"])
+		assert.Equal(t, "CPF", list.Data[0]["document_type"])
+		assert.Equal(t, "NATURAL", list.Data[0]["person_kind"])
+		assert.Equal(t, map[string]int{"total_items": 1, "total_pages": 1, "page": 1, "page_size": 10}, list.Meta)
+	})
+
+	t.Run("given person kind filter should return payees of kind", func(t *testing.T) {
+		assert.Len(t, listPayees(t, router, tenantID, "?person_kind=NATURAL").Data, 1)
+		assert.Empty(t, listPayees(t, router, tenantID, "?person_kind=LEGAL").Data)
+	})
+
+	t.Run("given an invalid person kind filter should return 422", func(t *testing.T) {
+		rec := doRequest(router, http.MethodGet, "/api/v1/payees?person_kind=ROBOT", tenantID, "")
+
+		assert.Equal(t, http.StatusUnprocessableEntity, rec.Code)
+	})
+
+	t.Run("given masked option should return masked values", func(t *testing.T) {
+		list := listPayees(t, router, tenantID, "?masked=true")
+
+		require.Len(t, list.Data, 1)
+		assert.Equal(t, "***.180.830-**", list.Data[0]["cpf_cnpj"])
+		assert.Equal(t, "***.180.830-**", list.Data[0]["pix_key"])
+		assert.Equal(t, "i***@feitosa.com", list.Data[0]["email"])
+	})
+}
+
+func TestPayeeHandler_Delete(t *testing.T) {
+	router := newTestRouter()
+	tenantID := uuid.NewString()
+	id := registerPayee(t, router, tenantID, validPayeeBody)
+
+	rec := doRequest(router, http.MethodDelete, "/api/v1/payees", tenantID, `{"ids": ["`+id+`"]}`)
+	require.Equal(t, http.StatusNoContent, rec.Code)
+
+	list := listPayees(t, router, tenantID, "")
+	assert.Empty(t, list.Data)
+}
+
+type fakeStatusChecker map[string]domain.DocumentRegistration
+
+func (c fakeStatusChecker) Check(_ context.Context, document domain.Document) (domain.DocumentRegistration, error) {
+	registration, ok := c[document.Value()]
+	if !ok {
+		return domain.DocumentRegistration{}, application.ErrDocumentRegistrationNotFound
+	}
+
+	return registration, nil
+}
+
+const validateBody = `{"bank_account": {
+	"account_type": "CONTA_CORRENTE",
+	"account_number": "65465465",
+	"account_digit": "5",
+	"branch_number": "0001",
+	"bank_code": "1",
+	"bank_ispb": "54545"
+}}`
+
+func TestPayeeHandler_Validate(t *testing.T) {
+	t.Run("given a draft payee should return 204 and turn it valid", func(t *testing.T) {
+		router := newTestRouter()
+		tenantID := uuid.NewString()
+		id := registerPayee(t, router, tenantID, validPayeeBody)
+
+		rec := doRequest(router, http.MethodPost, "/api/v1/payees/"+id+"/validate", tenantID, validateBody)
+		require.Equal(t, http.StatusNoContent, rec.Code, rec.Body.String())
+
+		list := listPayees(t, router, tenantID, "")
+		assert.Equal(t, "VALID", list.Data[0]["status"])
+		assert.Equal(t, "65465465", list.Data[0]["bank_account"].(map[string]any)["account_number"])
+
+		rec = doRequest(router, http.MethodPost, "/api/v1/payees/"+id+"/validate", tenantID, validateBody)
+		assert.Equal(t, http.StatusConflict, rec.Code)
+	})
+
+	checker := fakeStatusChecker{
+		"99818083008":    {Status: domain.RegularRegistrationStatus, OfficialName: "ITALO FEITOSA"},
+		"77386735081":    {Status: domain.DeceasedHolderRegistrationStatus, OfficialName: "MARIA SILVA"},
+		"19039318000104": {Status: domain.RegularRegistrationStatus, OfficialName: "OTHER COMPANY LTDA"},
+	}
+
+	tests := []struct {
+		name     string
+		body     string
+		wantCode int
+	}{
+		{
+			name:     "given a regular document with matching name should return 204",
+			body:     validPayeeBody,
+			wantCode: http.StatusNoContent,
+		},
+		{
+			name:     "given a document of deceased holder should return 422",
+			body:     `{"name": "Maria Silva", "cpf_cnpj": "77386735081", "pix_key_type": "CPF", "pix_key": "77386735081"}`,
+			wantCode: http.StatusUnprocessableEntity,
+		},
+		{
+			name:     "given a name different of official name should return 422",
+			body:     `{"name": "The Fake Company", "cpf_cnpj": "19039318000104", "pix_key_type": "CNPJ", "pix_key": "19039318000104"}`,
+			wantCode: http.StatusUnprocessableEntity,
+		},
+		{
+			name:     "given an unknown document should return 422",
+			body:     `{"name": "Italo Feitosa", "cpf_cnpj": "33860422014", "pix_key_type": "CPF", "pix_key": "33860422014"}`,
+			wantCode: http.StatusUnprocessableEntity,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router := newTestRouterWith(checker, memory.NewMailer())
+			tenantID := uuid.NewString()
+			id := registerPayee(t, router, tenantID, tt.body)
+
+			rec := doRequest(router, http.MethodPost, "/api/v1/payees/"+id+"/validate", tenantID, validateBody)
+
+			assert.Equal(t, tt.wantCode, rec.Code, rec.Body.String())
+		})
+	}
+
+	t.Run("given a valid payee should return 409 without checking document", func(t *testing.T) {
+		checker := fakeStatusChecker{"99818083008": {Status: domain.RegularRegistrationStatus, OfficialName: "ITALO FEITOSA"}}
+		router := newTestRouterWith(checker, memory.NewMailer())
+		tenantID := uuid.NewString()
+		id := registerPayee(t, router, tenantID, validPayeeBody)
+		rec := doRequest(router, http.MethodPost, "/api/v1/payees/"+id+"/validate", tenantID, validateBody)
+		require.Equal(t, http.StatusNoContent, rec.Code, rec.Body.String())
+
+		// a document check now would fail as not found
+		delete(checker, "99818083008")
+		rec = doRequest(router, http.MethodPost, "/api/v1/payees/"+id+"/validate", tenantID, validateBody)
+
+		assert.Equal(t, http.StatusConflict, rec.Code, rec.Body.String())
+	})
+}
@@ -0,0 +1,153 @@
+package api
+
+import (
+	"time"
+
+	"github.com/italorfeitosa/payee-account-manager-api/internal/domain"
+)
+
+type bankAccountResponse struct {
+	AccountType   string `json:"account_type"`
+	AccountNumber string `json:"account_number"`
+	AccountDigit  string `json:"account_digit"`
+	BranchNumber  string `json:"branch_number"`
+	BankCode      string `json:"bank_code"`
+	BankIspb      string `json:"bank_ispb"`
+}
+
+type inscricaoEstadualResponse struct {
+	UF    string `json:"uf"`
+	Value string `json:"value"`
+}
+
+type addressResponse struct {
+	Street       string `json:"street"`
+	Number       string `json:"number"`
+	Complement   string `json:"complement"`
+	Neighborhood string `json:"neighborhood"`
+	City         string `json:"city"`
+	UF           string `json:"uf"`
+	CEP          string `json:"cep"`
+}
+
+type contactResponse struct {
+	Type    string `json:"type"`
+	Value   string `json:"value"`
+	Primary bool   `json:"primary"`
+}
+
+type pixKeyResponse struct {
+	PixKeyType string `json:"pix_key_type"`
+	PixKey     string `json:"pix_key"`
+	Primary    bool   `json:"primary"`
+}
+
+type payeeResponse struct {
+	ID                string                     `json:"id"`
+	Name              string                     `json:"name"`
+	TradeName         string                     `json:"trade_name"`
+	InscricaoEstadual *inscricaoEstadualResponse `json:"inscricao_estadual"`
+	Document          string                     `json:"cpf_cnpj"`
+	DocumentType      string                     `json:"document_type"`
+	PersonKind        string                     `json:"person_kind"`
+	Email             string                     `json:"email"`
+	EmailVerified     bool                       `json:"email_verified"`
+	Address           *addressResponse           `json:"address"`
+	Contacts          []contactResponse          `json:"contacts"`
+	PixKeyType        string                     `json:"pix_key_type"`
+	PixKey            string                     `json:"pix_key"`
+	PixKeys           []pixKeyResponse           `json:"pix_keys"`
+	Status            string                     `json:"status"`
+	BankAccount       *bankAccountResponse       `json:"bank_account"`
+	CreatedAt         time.Time                  `json:"created_at"`
+	UpdatedAt         time.Time                  `json:"updated_at"`
+}
+
+// newPayeeResponse maps payee to response body, pix_key_type and pix_key are the primary pix key.
+// When masked is true cpf_cnpj, email, contacts, pix keys and bank account number are returned masked
+func newPayeeResponse(payee *domain.PayeeEntity, masked bool) payeeResponse {
+	response := payeeResponse{
+		ID:            payee.ID(),
+		Name:          payee.Name(),
+		TradeName:     payee.TradeName(),
+		Document:      payee.Document().Value(),
+		DocumentType:  string(payee.DocumentType()),
+		PersonKind:    string(payee.PersonKind()),
+		Email:         payee.Email(),
+		EmailVerified: payee.EmailVerified(),
+		PixKeyType:    payee.PixKey().Type(),
+		PixKey:        payee.PixKey().Value(),
+		Status:        payee.Status().Value(),
+		CreatedAt:     payee.CreatedAt(),
+		UpdatedAt:     payee.UpdatedAt(),
+	}
+
+	if masked {
+		response.Document = payee.Document().Masked()
+		response.Email = payee.MaskedEmail()
+		response.PixKey = payee.PixKey().Masked()
+	}
+
+	if ie := payee.InscricaoEstadual(); ie != nil {
+		response.InscricaoEstadual = &inscricaoEstadualResponse{UF: ie.UF().String(), Value: ie.Value()}
+	}
+
+	if address := payee.Address(); address != nil {
+		response.Address = &addressResponse{
+			Street:       address.Street(),
+			Number:       address.Number(),
+			Complement:   address.Complement(),
+			Neighborhood: address.Neighborhood(),
+			City:         address.City(),
+			UF:           address.UF().String(),
+			CEP:          address.CEP().String(),
+		}
+	}
+
+	response.Contacts = make([]contactResponse, 0, len(payee.Contacts()))
+	for _, contact := range payee.Contacts() {
+		value := contact.Value()
+		if masked {
+			value = contact.Masked()
+		}
+
+		response.Contacts = append(response.Contacts, contactResponse{
+			Type:    string(contact.Type()),
+			Value:   value,
+			Primary: contact.Primary(),
+		})
+	}
+
+	for i, key := range payee.PixKeys() {
+		pixKey := pixKeyResponse{PixKeyType: key.Type(), PixKey: key.Value(), Primary: i == 0}
+		if masked {
+			pixKey.PixKey = key.Masked()
+		}
+
+		response.PixKeys = append(response.PixKeys, pixKey)
+	}
+
+	if bankAccount := payee.BankAccount(); bankAccount != nil {
+		response.BankAccount = &bankAccountResponse{
+			AccountType:   bankAccount.AccountType,
+			AccountNumber: bankAccount.AccountNumber,
+			AccountDigit:  bankAccount.AccountDigit,
+			BranchNumber:  bankAccount.BranchNumber,
+			BankCode:      bankAccount.BankCode,
+			BankIspb:      bankAccount.BankIspb,
+		}
+
+		if masked {
+			response.BankAccount.AccountNumber = bankAccount.MaskedAccountNumber()
+		}
+	}
+
+	return response
+}
+
+type pageMetadata struct {
+	TotalItems int `json:"total_items"`
+	TotalPages int `json:"total_pages"`
+	Page       int `json:"page"`
+	PageSize   int `json:"page_size"`
+}
@@ -0,0 +1,72 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+
+	"github.com/italorfeitosa/payee-account-manager-api/internal/application"
+)
+
+type pixKeyRequest struct {
+	PixKeyType string `json:"pix_key_type"`
+	PixKey     string `json:"pix_key"`
+}
+
+// PixKeyHandler handles http requests to manage the pix keys of a payee
+type PixKeyHandler struct {
+	addPixKey        *application.AddPixKeyUseCase
+	removePixKey     *application.RemovePixKeyUseCase
+	setPrimaryPixKey *application.SetPrimaryPixKeyUseCase
+}
+
+func NewPixKeyHandler(
+	addPixKey *application.AddPixKeyUseCase,
+	removePixKey *application.RemovePixKeyUseCase,
+	setPrimaryPixKey *application.SetPrimaryPixKeyUseCase,
+) *PixKeyHandler {
+	return &PixKeyHandler{
+		addPixKey:        addPixKey,
+		removePixKey:     removePixKey,
+		setPrimaryPixKey: setPrimaryPixKey,
+	}
+}
+
+type pixKeyUseCase func(ctx context.Context, tenantID, payeeID string, input application.PixKeyInput) error
+
+// handle decodes pix key body and executes the use case, returning 204 on success
+func (h *PixKeyHandler) handle(useCase pixKeyUseCase) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var body pixKeyRequest
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			writeError(w, http.StatusBadRequest, "invalid request body")
+			return
+		}
+
+		err := useCase(r.Context(), tenantFromContext(r.Context()), r.PathValue("payee_id"), application.PixKeyInput{
+			PixKeyType: body.PixKeyType,
+			PixKey:     body.PixKey,
+		})
+		if err != nil {
+			writeUseCaseError(w, err)
+			return
+		}
+
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+// Add handles POST api/v1/payees/{payee_id}/pix-keys
+func (h *PixKeyHandler) Add(w http.ResponseWriter, r *http.Request) {
+	h.handle(h.addPixKey.Execute)(w, r)
+}
+
+// Remove handles DELETE api/v1/payees/{payee_id}/pix-keys
+func (h *PixKeyHandler) Remove(w http.ResponseWriter, r *http.Request) {
+	h.handle(h.removePixKey.Execute)(w, r)
+}
+
+// SetPrimary handles PUT api/v1/payees/{payee_id}/pix-keys/primary
+func (h *PixKeyHandler) SetPrimary(w http.ResponseWriter, r *http.Request) {
+	h.handle(h.setPrimaryPixKey.Execute)(w, r)
+}
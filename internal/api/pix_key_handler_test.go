@@ -0,0 +1,57 @@
+package api_test
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPixKeyHandler(t *testing.T) {
+	router := newTestRouter()
+	tenantID := uuid.NewString()
+	id := registerPayee(t, router, tenantID, validPayeeBody)
+	pixKeysPath := "/api/v1/payees/" + id + "/pix-keys"
+
+	t.Run("given a new pix key should add it as non primary", func(t *testing.T) {
+		rec := doRequest(router, http.MethodPost, pixKeysPath, tenantID, `{"pix_key": "+5511912345678"}`)
+		require.Equal(t, http.StatusNoContent, rec.Code, rec.Body.String())
+
+		list := listPayees(t, router, tenantID, "")
+		assert.Equal(t, []any{
+			map[string]any{"pix_key_type": "CPF", "pix_key": "99818083008", "primary": true},
+			map[string]any{"pix_key_type": "TELEFONE", "pix_key": "5511912345678", "primary": false},
+		}, list.Data[0]["pix_keys"])
+	})
+
+	t.Run("given a duplicated pix key should return 422", func(t *testing.T) {
+		rec := doRequest(router, http.MethodPost, pixKeysPath, tenantID, `{"pix_key_type": "TELEFONE", "pix_key": "11912345678"}`)
+
+		assert.Equal(t, http.StatusUnprocessableEntity, rec.Code)
+	})
+
+	t.Run("given a non primary pix key without type should detect it and set it as primary", func(t *testing.T) {
+		rec := doRequest(router, http.MethodPut, pixKeysPath+"/primary", tenantID, `{"pix_key": "+5511912345678"}`)
+		require.Equal(t, http.StatusNoContent, rec.Code, rec.Body.String())
+
+		list := listPayees(t, router, tenantID, "")
+		assert.Equal(t, "TELEFONE", list.Data[0]["pix_key_type"])
+		assert.Equal(t, "5511912345678", list.Data[0]["pix_key"])
+	})
+
+	t.Run("given a non primary pix key should remove it", func(t *testing.T) {
+		rec := doRequest(router, http.MethodDelete, pixKeysPath, tenantID, `{"pix_key_type": "CPF", "pix_key": "99818083008"}`)
+		require.Equal(t, http.StatusNoContent, rec.Code, rec.Body.String())
+
+		list := listPayees(t, router, tenantID, "")
+		assert.Len(t, list.Data[0]["pix_keys"], 1)
+	})
+
+	t.Run("given an unknown pix key should return 404", func(t *testing.T) {
+		rec := doRequest(router, http.MethodDelete, pixKeysPath, tenantID, `{"pix_key_type": "CPF", "pix_key": "99818083008"}`)
+
+		assert.Equal(t, http.StatusNotFound, rec.Code)
+	})
+}
@@ -0,0 +1,122 @@
+package api
+
+import (
+	"math"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// RateLimits are the token bucket settings of a tenant, reads are GET requests and writes any other method.
+// Zero reads or writes per second turns off the limit of that kind of request
+type RateLimits struct {
+	ReadsPerSecond  float64
+	ReadBurst       int
+	WritesPerSecond float64
+	WriteBurst      int
+}
+
+// RateLimiter limits requests by tenant with a token bucket for reads and another for writes
+type RateLimiter struct {
+	limits  func(tenantID string) RateLimits
+	mu      sync.Mutex
+	buckets map[bucketKey]*tokenBucket
+}
+
+type bucketKey struct {
+	tenantID string
+	write    bool
+}
+
+// NewRateLimiter returns the limiter, limits is called once per tenant and request kind
+func NewRateLimiter(limits func(tenantID string) RateLimits) *RateLimiter {
+	return &RateLimiter{limits: limits, buckets: make(map[bucketKey]*tokenBucket)}
+}
+
+// tokenBucket refills rate tokens per second up to burst, each request takes one token
+type tokenBucket struct {
+	rate   float64
+	burst  float64
+	tokens float64
+	last   time.Time
+}
+
+// take refills bucket until now and takes a token when available, it returns the tokens left
+// and how long until a token is available, zero when taken
+func (b *tokenBucket) take(now time.Time) (remaining float64, wait time.Duration) {
+	b.tokens = math.Min(b.burst, b.tokens+now.Sub(b.last).Seconds()*b.rate)
+	b.last = now
+
+	if b.tokens < 1 {
+		return b.tokens, secondsToDuration((1 - b.tokens) / b.rate)
+	}
+
+	b.tokens--
+
+	return b.tokens, 0
+}
+
+// untilFull returns how long bucket takes to refill completely
+func (b *tokenBucket) untilFull() time.Duration {
+	return secondsToDuration((b.burst - b.tokens) / b.rate)
+}
+
+func secondsToDuration(seconds float64) time.Duration {
+	return time.Duration(seconds * float64(time.Second))
+}
+
+func (l *RateLimiter) bucket(tenantID string, write bool) *tokenBucket {
+	key := bucketKey{tenantID, write}
+
+	bucket, ok := l.buckets[key]
+	if !ok {
+		limits := l.limits(tenantID)
+		rate, burst := limits.ReadsPerSecond, limits.ReadBurst
+		if write {
+			rate, burst = limits.WritesPerSecond, limits.WriteBurst
+		}
+
+		bucket = &tokenBucket{rate: rate, burst: float64(burst), tokens: float64(burst), last: time.Now()}
+		l.buckets[key] = bucket
+	}
+
+	return bucket
+}
+
+// limit rejects with 429 requests of tenants that exhausted their bucket, it must run after authenticate.
+// Every limited response carries RateLimit-Limit, RateLimit-Remaining and RateLimit-Reset headers
+func (l *RateLimiter) limit(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		write := r.Method != http.MethodGet && r.Method != http.MethodHead
+
+		l.mu.Lock()
+		bucket := l.bucket(tenantFromContext(r.Context()), write)
+		if bucket.rate == 0 {
+			l.mu.Unlock()
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		remaining, wait := bucket.take(time.Now())
+		reset := bucket.untilFull()
+		l.mu.Unlock()
+
+		w.Header().Set("RateLimit-Limit", strconv.Itoa(int(bucket.burst)))
+		w.Header().Set("RateLimit-Remaining", strconv.Itoa(int(remaining)))
+		w.Header().Set("RateLimit-Reset", strconv.Itoa(ceilSeconds(reset)))
+
+		if wait > 0 {
+			w.Header().Set("Retry-After", strconv.Itoa(ceilSeconds(wait)))
+			writeError(w, http.StatusTooManyRequests, "rate limit exceeded")
+			return
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+// ceilSeconds rounds d up to whole seconds, as rate limit headers are in seconds
+func ceilSeconds(d time.Duration) int {
+	return int(math.Ceil(d.Seconds()))
+}
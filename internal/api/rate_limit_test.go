@@ -0,0 +1,155 @@
+package api_test
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/italorfeitosa/payee-account-manager-api/internal/api"
+	"github.com/italorfeitosa/payee-account-manager-api/internal/infra/memory"
+	"github.com/italorfeitosa/payee-account-manager-api/test/fake"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRateLimiter(t *testing.T) {
+	limitedTenantID := uuid.NewString()
+
+	// refill is slow enough to not add tokens while test runs
+	limits := func(tenantID string) api.RateLimits {
+		if tenantID == limitedTenantID {
+			return api.RateLimits{ReadsPerSecond: 0.01, ReadBurst: 1, WritesPerSecond: 0.01, WriteBurst: 2}
+		}
+
+		return testRateLimits
+	}
+
+	t.Run("given writes within burst should return rate limit headers", func(t *testing.T) {
+		router := newConfiguredTestRouter(nil, memory.NewMailer(), api.NewMetrics(), limits, nil, memory.NewAuditLog())
+
+		rec := doRequest(router, http.MethodDelete, "/api/v1/payees", limitedTenantID, `{"ids": []}`)
+
+		require.Equal(t, http.StatusNoContent, rec.Code)
+		assert.Equal(t, "2", rec.Header().Get("RateLimit-Limit"))
+		assert.Equal(t, "1", rec.Header().Get("RateLimit-Remaining"))
+		assert.Equal(t, "100", rec.Header().Get("RateLimit-Reset"))
+		assert.Empty(t, rec.Header().Get("Retry-After"))
+	})
+
+	t.Run("given exhausted writes should return 429 with retry after", func(t *testing.T) {
+		router := newConfiguredTestRouter(nil, memory.NewMailer(), api.NewMetrics(), limits, nil, memory.NewAuditLog())
+
+		for range 2 {
+			rec := doRequest(router, http.MethodDelete, "/api/v1/payees", limitedTenantID, `{"ids": []}`)
+			require.Equal(t, http.StatusNoContent, rec.Code)
+		}
+
+		rec := doRequest(router, http.MethodDelete, "/api/v1/payees", limitedTenantID, `{"ids": []}`)
+
+		assert.Equal(t, http.StatusTooManyRequests, rec.Code)
+		assert.JSONEq(t, `{"error": "rate limit exceeded"}`, rec.Body.String())
+		assert.Equal(t, "0", rec.Header().Get("RateLimit-Remaining"))
+
+		retryAfter, err := strconv.Atoi(rec.Header().Get("Retry-After"))
+		require.NoError(t, err)
+		assert.InDelta(t, 100, retryAfter, 1)
+	})
+
+	t.Run("given exhausted writes should still allow reads and other tenants", func(t *testing.T) {
+		router := newConfiguredTestRouter(nil, memory.NewMailer(), api.NewMetrics(), limits, nil, memory.NewAuditLog())
+
+		for range 3 {
+			doRequest(router, http.MethodDelete, "/api/v1/payees", limitedTenantID, `{"ids": []}`)
+		}
+
+		rec := doRequest(router, http.MethodGet, "/api/v1/payees", limitedTenantID, "")
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Equal(t, "1", rec.Header().Get("RateLimit-Limit"))
+
+		rec = doRequest(router, http.MethodDelete, "/api/v1/payees", uuid.NewString(), `{"ids": []}`)
+		assert.Equal(t, http.StatusNoContent, rec.Code)
+		assert.Equal(t, "1000", rec.Header().Get("RateLimit-Limit"))
+	})
+
+	t.Run("given zero writes per second should not limit writes", func(t *testing.T) {
+		unlimitedTenantID := uuid.NewString()
+		limits := func(string) api.RateLimits {
+			return api.RateLimits{ReadsPerSecond: 0.01, ReadBurst: 1}
+		}
+		router := newConfiguredTestRouter(nil, memory.NewMailer(), api.NewMetrics(), limits, nil, memory.NewAuditLog())
+
+		for range 5 {
+			rec := doRequest(router, http.MethodDelete, "/api/v1/payees", unlimitedTenantID, `{"ids": []}`)
+			require.Equal(t, http.StatusNoContent, rec.Code)
+			assert.Empty(t, rec.Header().Get("RateLimit-Limit"))
+		}
+	})
+}
+
+func TestRegistrationQuota(t *testing.T) {
+	quota := func(string) int { return 1 }
+	router := newConfiguredTestRouter(nil, memory.NewMailer(), api.NewMetrics(), func(string) api.RateLimits { return testRateLimits }, quota, memory.NewAuditLog())
+	tenantID := uuid.NewString()
+
+	id := registerPayee(t, router, tenantID, validPayeeBody)
+
+	t.Run("given quota reached should return 429 until next day", func(t *testing.T) {
+		body := strings.ReplaceAll(validPayeeBody, "99818083008", "52998224725")
+
+		rec := doRequest(router, http.MethodPost, "/api/v1/payees", tenantID, body)
+
+		assert.Equal(t, http.StatusTooManyRequests, rec.Code)
+		assert.Contains(t, rec.Body.String(), "daily payee registration quota exceeded")
+
+		retryAfter, err := strconv.Atoi(rec.Header().Get("Retry-After"))
+		require.NoError(t, err)
+		assert.Greater(t, retryAfter, 0)
+		assert.LessOrEqual(t, retryAfter, 24*60*60)
+	})
+
+	t.Run("given deleted payee should not restore quota", func(t *testing.T) {
+		rec := doRequest(router, http.MethodDelete, "/api/v1/payees", tenantID, `{"ids": ["`+id+`"]}`)
+		require.Equal(t, http.StatusNoContent, rec.Code)
+
+		rec = doRequest(router, http.MethodPost, "/api/v1/payees", tenantID, validPayeeBody)
+		assert.Equal(t, http.StatusTooManyRequests, rec.Code)
+	})
+
+	t.Run("given other tenant should have its own quota", func(t *testing.T) {
+		registerPayee(t, router, uuid.NewString(), validPayeeBody)
+	})
+}
+
+func TestRegistrationQuota_Concurrent(t *testing.T) {
+	limit := 3
+	quota := func(string) int { return limit }
+	router := newConfiguredTestRouter(nil, memory.NewMailer(), api.NewMetrics(), func(string) api.RateLimits { return testRateLimits }, quota, memory.NewAuditLog())
+	tenantID := uuid.NewString()
+
+	var created, exceeded atomic.Int64
+	var wg sync.WaitGroup
+	for range 20 {
+		cpf := fake.UnformattedCPF()
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			rec := doRequest(router, http.MethodPost, "/api/v1/payees", tenantID, strings.ReplaceAll(validPayeeBody, "99818083008", cpf))
+			switch rec.Code {
+			case http.StatusCreated:
+				created.Add(1)
+			case http.StatusTooManyRequests:
+				exceeded.Add(1)
+			}
+		}()
+	}
+	wg.Wait()
+
+	assert.EqualValues(t, limit, created.Load())
+	assert.EqualValues(t, 20-limit, exceeded.Load())
+}
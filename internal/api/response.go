@@ -0,0 +1,135 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/italorfeitosa/payee-account-manager-api/internal/application"
+	"github.com/italorfeitosa/payee-account-manager-api/internal/domain"
+)
+
+type dataResponse struct {
+	Data     any `json:"data"`
+	Metadata any `json:"metadata,omitempty"`
+}
+
+type errorResponse struct {
+	Error string `json:"error"`
+}
+
+type conflictResponse struct {
+	Error           string `json:"error"`
+	Field           string `json:"field"`
+	ExistingPayeeID string `json:"existing_payee_id"`
+}
+
+func writeJSON(w http.ResponseWriter, status int, body any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+
+	if err := json.NewEncoder(w).Encode(body); err != nil {
+		slog.Error("failed to encode response body", slog.String("error", err.Error()))
+	}
+}
+
+func writeError(w http.ResponseWriter, status int, message string) {
+	writeJSON(w, status, errorResponse{Error: message})
+}
+
+// validationErrors are domain errors caused by invalid input values, named by their
+// sentinel to label validation failure metrics
+var validationErrors = []struct {
+	name string
+	err  error
+}{
+	{"ErrNameEmptyString", domain.ErrNameEmptyString},
+	{"ErrNameLessThenTwoWords", domain.ErrNameLessThenTwoWords},
+	{"ErrShortFirstName", domain.ErrShortFirstName},
+	{"ErrNameTooShort", domain.ErrNameTooShort},
+	{"ErrNameTooLong", domain.ErrNameTooLong},
+	{"ErrNameOnlySuffix", domain.ErrNameOnlySuffix},
+	{"ErrTradeNameNotAllowed", domain.ErrTradeNameNotAllowed},
+	{"ErrInscricaoEstadualNotAllowed", domain.ErrInscricaoEstadualNotAllowed},
+	{"ErrInvalidInscricaoEstadual", domain.ErrInvalidInscricaoEstadual},
+	{"ErrInvalidDocument", domain.ErrInvalidDocument},
+	{"ErrInvalidCPF", domain.ErrInvalidCPF},
+	{"ErrInvalidCNPJ", domain.ErrInvalidCNPJ},
+	{"ErrInvalidCNPJRoot", domain.ErrInvalidCNPJRoot},
+	{"ErrInvalidEmail", domain.ErrInvalidEmail},
+	{"ErrInvalidPersonKind", domain.ErrInvalidPersonKind},
+	{"ErrInvalidPixKeyType", domain.ErrInvalidPixKeyType},
+	{"ErrAmbiguousPixKey", domain.ErrAmbiguousPixKey},
+	{"ErrUndetectablePixKey", domain.ErrUndetectablePixKey},
+	{"ErrDuplicatedPixKey", domain.ErrDuplicatedPixKey},
+	{"ErrPrimaryPixKeyRemoval", domain.ErrPrimaryPixKeyRemoval},
+	{"ErrIncompleteBankAccount", domain.ErrIncompleteBankAccount},
+	{"ErrIrregularDocument", domain.ErrIrregularDocument},
+	{"ErrOfficialNameMismatch", domain.ErrOfficialNameMismatch},
+	{"ErrDocumentRegistrationNotFound", application.ErrDocumentRegistrationNotFound},
+	{"ErrEmailNotSet", domain.ErrEmailNotSet},
+	{"ErrEmailVerificationMismatch", domain.ErrEmailVerificationMismatch},
+	{"ErrInvalidEmailVerificationToken", application.ErrInvalidEmailVerificationToken},
+	{"ErrExpiredEmailVerificationToken", application.ErrExpiredEmailVerificationToken},
+	{"ErrIncompleteAddress", domain.ErrIncompleteAddress},
+	{"ErrAddressTooLong", domain.ErrAddressTooLong},
+	{"ErrInvalidUF", domain.ErrInvalidUF},
+	{"ErrInvalidCEP", domain.ErrInvalidCEP},
+	{"ErrCEPUFMismatch", domain.ErrCEPUFMismatch},
+	{"ErrInvalidContactType", domain.ErrInvalidContactType},
+	{"ErrInvalidPhone", domain.ErrInvalidPhone},
+	{"ErrDuplicatedContact", domain.ErrDuplicatedContact},
+	{"ErrPrimaryContactRequired", domain.ErrPrimaryContactRequired},
+	{"ErrInvalidTelefone", domain.ErrInvalidTelefone},
+	{"ErrInvalidChaveAleatoria", domain.ErrInvalidChaveAleatoria},
+}
+
+// validationErrorName returns the sentinel name of err when it is a validation error
+func validationErrorName(err error) (string, bool) {
+	for _, validationErr := range validationErrors {
+		if errors.Is(err, validationErr.err) {
+			return validationErr.name, true
+		}
+	}
+
+	return "", false
+}
+
+// writeUseCaseError translates use case errors to http responses
+func writeUseCaseError(w http.ResponseWriter, err error) {
+	var conflict *application.ConflictError
+	var quotaExceeded *application.QuotaExceededError
+	errorName, isValidationError := validationErrorName(err)
+
+	switch {
+	case errors.As(err, &conflict):
+		writeJSON(w, http.StatusConflict, conflictResponse{
+			Error:           err.Error(),
+			Field:           conflict.Field,
+			ExistingPayeeID: conflict.ExistingPayeeID,
+		})
+	case errors.As(err, &quotaExceeded):
+		w.Header().Set("Retry-After", strconv.Itoa(ceilSeconds(time.Until(quotaExceeded.ResetAt))))
+		writeError(w, http.StatusTooManyRequests, err.Error())
+	case errors.Is(err, application.ErrForbidden):
+		writeError(w, http.StatusForbidden, err.Error())
+	case errors.Is(err, application.ErrPayeeNotFound), errors.Is(err, domain.ErrPixKeyNotFound):
+		writeError(w, http.StatusNotFound, err.Error())
+	case errors.Is(err, application.ErrInvalidIdempotencyKey):
+		writeError(w, http.StatusBadRequest, err.Error())
+	case errors.Is(err, application.ErrIdempotencyKeyReused):
+		writeError(w, http.StatusUnprocessableEntity, err.Error())
+	case errors.Is(err, domain.ErrPixKeysNotEditable), errors.Is(err, domain.ErrPayeeNotDraft),
+		errors.Is(err, domain.ErrPayeeAnonymized), errors.Is(err, application.ErrIdempotentRequestInProgress):
+		writeError(w, http.StatusConflict, err.Error())
+	case isValidationError:
+		recordValidationError(w, errorName)
+		writeError(w, http.StatusUnprocessableEntity, err.Error())
+	default:
+		recordUnexpectedError(w, err)
+		writeError(w, http.StatusInternalServerError, "internal server error")
+	}
+}
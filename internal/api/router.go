@@ -0,0 +1,51 @@
+package api
+
+import (
+	"net/http"
+)
+
+// NewRouter returns the http handler with all api/v1 routes and probes. Metrics carry tenant ids,
+// so they are not routed here and must be served on an internal listener with Metrics.Handler
+func NewRouter(
+	payees *PayeeHandler,
+	pixKeys *PixKeyHandler,
+	companies *CompanyHandler,
+	emailVerifications *EmailVerificationHandler,
+	dataSubjects *DataSubjectHandler,
+	health *HealthHandler,
+	metrics *Metrics,
+	limiter *RateLimiter,
+	auth *Authenticator,
+) http.Handler {
+	mux := http.NewServeMux()
+	handle := func(pattern string, handler http.Handler) {
+		mux.Handle(pattern, instrument(pattern, metrics, handler))
+	}
+	tenantScoped := func(handler http.HandlerFunc) http.Handler {
+		return auth.authenticate(limiter.limit(handler))
+	}
+
+	mux.HandleFunc("GET /healthz", health.Live)
+	mux.HandleFunc("GET /readyz", health.Ready)
+
+	handle("POST /api/v1/payees", tenantScoped(payees.Register))
+	handle("GET /api/v1/payees", tenantScoped(payees.List))
+	handle("DELETE /api/v1/payees", tenantScoped(payees.Delete))
+	handle("PUT /api/v1/payees/{payee_id}", tenantScoped(payees.Edit))
+	handle("POST /api/v1/payees/{payee_id}/validate", tenantScoped(payees.Validate))
+
+	handle("POST /api/v1/payees/{payee_id}/pix-keys", tenantScoped(pixKeys.Add))
+	handle("DELETE /api/v1/payees/{payee_id}/pix-keys", tenantScoped(pixKeys.Remove))
+	handle("PUT /api/v1/payees/{payee_id}/pix-keys/primary", tenantScoped(pixKeys.SetPrimary))
+
+	handle("GET /api/v1/companies", tenantScoped(companies.List))
+
+	handle("POST /api/v1/payees/{payee_id}/email-verification", tenantScoped(emailVerifications.Send))
+	// confirmation link is opened by payee, so tenant comes from token instead of header
+	handle("POST /api/v1/email-verifications/confirm", http.HandlerFunc(emailVerifications.Confirm))
+
+	handle("POST /api/v1/data-subjects/export", tenantScoped(dataSubjects.Export))
+	handle("POST /api/v1/data-subjects/anonymize", tenantScoped(dataSubjects.Anonymize))
+
+	return mux
+}
@@ -0,0 +1,15 @@
+package api
+
+import (
+	"context"
+)
+
+// TenantIDHeader is optional, as tenant comes from bearer token, but when sent it must match the token tenant
+const TenantIDHeader = "tenant-id"
+
+type tenantContextKey struct{}
+
+func tenantFromContext(ctx context.Context) string {
+	tenantID, _ := ctx.Value(tenantContextKey{}).(string)
+	return tenantID
+}
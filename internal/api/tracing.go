@@ -0,0 +1,50 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/italorfeitosa/payee-account-manager-api/internal/application"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// TracerName is the instrumentation name of http spans
+const TracerName = "github.com/italorfeitosa/payee-account-manager-api/internal/api"
+
+var tracer = otel.Tracer(TracerName)
+
+// traceContext propagates W3C traceparent and tracestate headers
+var traceContext = propagation.TraceContext{}
+
+// startRequestSpan starts a server span named by pattern ("METHOD /path"), continuing the trace
+// of traceparent header when present. Tenant is tagged by authenticate after validation
+func startRequestSpan(ctx context.Context, r *http.Request, pattern, method, route string) (context.Context, trace.Span) {
+	ctx = traceContext.Extract(ctx, propagation.HeaderCarrier(r.Header))
+
+	attrs := []attribute.KeyValue{
+		attribute.String("http.request.method", method),
+		attribute.String("http.route", route),
+	}
+	if payeeID := r.PathValue("payee_id"); payeeID != "" {
+		attrs = append(attrs, application.PayeeIDAttribute.String(payeeID))
+	}
+
+	return tracer.Start(ctx, pattern, trace.WithSpanKind(trace.SpanKindServer), trace.WithAttributes(attrs...))
+}
+
+// endRequestSpan tags span with response status and ends it
+func endRequestSpan(span trace.Span, status int) {
+	span.SetAttributes(attribute.Int("http.response.status_code", status))
+
+	// client errors are not failures of the server span
+	if status >= http.StatusInternalServerError {
+		span.SetStatus(codes.Error, fmt.Sprintf("http status %d", status))
+	}
+
+	span.End()
+}
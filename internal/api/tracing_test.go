@@ -0,0 +1,124 @@
+package api_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/italorfeitosa/payee-account-manager-api/internal/application"
+	"github.com/italorfeitosa/payee-account-manager-api/internal/infra/tracing"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+var (
+	setupTracing sync.Once
+	spanExporter *tracetest.InMemoryExporter
+)
+
+// recordSpans registers the in-memory provider once, as tracers are bound to first global provider
+func recordSpans() *tracetest.InMemoryExporter {
+	setupTracing.Do(func() {
+		spanExporter = tracetest.NewInMemoryExporter()
+		tracing.Setup(sdktrace.NewTracerProvider(sdktrace.WithSyncer(spanExporter)))
+	})
+
+	return spanExporter
+}
+
+func spansOfTrace(exporter *tracetest.InMemoryExporter, traceID string) map[string]tracetest.SpanStub {
+	spans := make(map[string]tracetest.SpanStub)
+	for _, span := range exporter.GetSpans() {
+		if span.SpanContext.TraceID().String() == traceID {
+			spans[span.Name] = span
+		}
+	}
+
+	return spans
+}
+
+func attributeValue(span tracetest.SpanStub, key attribute.Key) string {
+	for _, attr := range span.Attributes {
+		if attr.Key == key {
+			return attr.Value.Emit()
+		}
+	}
+
+	return ""
+}
+
+func TestTracing(t *testing.T) {
+	exporter := recordSpans()
+	router := newTestRouter()
+	tenantID := uuid.NewString()
+
+	const (
+		traceID      = "4bf92f3577b34da6a3ce929d0e0e4736"
+		parentSpanID = "00f067aa0ba902b7"
+	)
+
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/payees", strings.NewReader(validPayeeBody))
+	authorize(req, tenantID)
+	req.Header.Set("traceparent", "00-"+traceID+"-"+parentSpanID+"-01")
+
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+	require.Equal(t, http.StatusCreated, rec.Code, rec.Body.String())
+
+	spans := spansOfTrace(exporter, traceID)
+	require.Contains(t, spans, "POST /api/v1/payees")
+	require.Contains(t, spans, "RegisterPayee")
+	require.Contains(t, spans, "PayeeRepository.Save")
+
+	t.Run("given traceparent header should continue the trace", func(t *testing.T) {
+		server := spans["POST /api/v1/payees"]
+
+		assert.Equal(t, parentSpanID, server.Parent.SpanID().String())
+		assert.True(t, server.Parent.IsRemote())
+		assert.Equal(t, trace.SpanKindServer, server.SpanKind)
+		assert.Equal(t, "201", attributeValue(server, "http.response.status_code"))
+	})
+
+	t.Run("given registration should nest use case and repository spans", func(t *testing.T) {
+		assert.Equal(t, spans["POST /api/v1/payees"].SpanContext.SpanID(), spans["RegisterPayee"].Parent.SpanID())
+		assert.Equal(t, spans["RegisterPayee"].SpanContext.SpanID(), spans["PayeeRepository.Save"].Parent.SpanID())
+	})
+
+	t.Run("given registration should tag spans with tenant and payee ids", func(t *testing.T) {
+		payeeID := attributeValue(spans["RegisterPayee"], application.PayeeIDAttribute)
+		require.NotEmpty(t, payeeID)
+
+		for _, name := range []string{"POST /api/v1/payees", "RegisterPayee", "PayeeRepository.Save"} {
+			assert.Equal(t, tenantID, attributeValue(spans[name], application.TenantIDAttribute), name)
+		}
+		assert.Equal(t, payeeID, attributeValue(spans["PayeeRepository.Save"], application.PayeeIDAttribute))
+	})
+
+	t.Run("given authenticated request should tag http and use case spans with actor", func(t *testing.T) {
+		for _, name := range []string{"POST /api/v1/payees", "RegisterPayee"} {
+			assert.Equal(t, testSubject, attributeValue(spans[name], application.ActorAttribute), name)
+		}
+	})
+
+	t.Run("given path with payee id should tag http span with payee id", func(t *testing.T) {
+		payeeID := attributeValue(spans["RegisterPayee"], application.PayeeIDAttribute)
+
+		req := httptest.NewRequest(http.MethodPut, "/api/v1/payees/"+payeeID, strings.NewReader(validPayeeBody))
+		authorize(req, tenantID)
+		req.Header.Set("traceparent", "00-4bf92f3577b34da6a3ce929d0e0e4737-"+parentSpanID+"-01")
+		router.ServeHTTP(httptest.NewRecorder(), req)
+
+		spans := spansOfTrace(exporter, "4bf92f3577b34da6a3ce929d0e0e4737")
+		require.Contains(t, spans, "PUT /api/v1/payees/{payee_id}")
+		assert.Equal(t, payeeID, attributeValue(spans["PUT /api/v1/payees/{payee_id}"], application.PayeeIDAttribute))
+		assert.Contains(t, spans, "EditPayee")
+		assert.Contains(t, spans, "PayeeRepository.FindByID")
+	})
+}
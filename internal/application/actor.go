@@ -0,0 +1,35 @@
+package application
+
+import (
+	"context"
+)
+
+type actorContextKey struct{}
+
+type actor struct {
+	subject string
+	roles   []Role
+}
+
+// WithActor returns ctx carrying the actor, who is the authenticated subject acting on use cases
+// with the given roles. Actor is recorded in audit entries and in use case spans
+func WithActor(ctx context.Context, subject string, roles ...Role) context.Context {
+	return context.WithValue(ctx, actorContextKey{}, actor{subject, roles})
+}
+
+// ActorFromContext returns the actor set by WithActor, or empty when there is none
+func ActorFromContext(ctx context.Context) string {
+	actor, _ := ctx.Value(actorContextKey{}).(actor)
+	return actor.subject
+}
+
+// RolesFromContext returns the roles of actor set by WithActor
+func RolesFromContext(ctx context.Context) []Role {
+	actor, _ := ctx.Value(actorContextKey{}).(actor)
+	return actor.roles
+}
+
+// payeeActor is the actor of operations done by payee itself, like confirming its email
+func payeeActor(payeeID string) string {
+	return "payee:" + payeeID
+}
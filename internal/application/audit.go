@@ -0,0 +1,51 @@
+package application
+
+import (
+	"context"
+	"time"
+)
+
+// AuditAction is what an actor did to a payee
+type AuditAction string
+
+const (
+	PayeeRegisteredAction       AuditAction = "payee.registered"
+	PayeeEditedAction           AuditAction = "payee.edited"
+	PayeeValidatedAction        AuditAction = "payee.validated"
+	PayeeDeletedAction          AuditAction = "payee.deleted"
+	PixKeyAddedAction           AuditAction = "pix_key.added"
+	PixKeyRemovedAction         AuditAction = "pix_key.removed"
+	PrimaryPixKeySetAction      AuditAction = "pix_key.primary_set"
+	EmailVerificationSentAction AuditAction = "email.verification_sent"
+	EmailConfirmedAction        AuditAction = "email.confirmed"
+	DataSubjectExportedAction   AuditAction = "data_subject.exported"
+	PayeeAnonymizedAction       AuditAction = "payee.anonymized"
+)
+
+// AuditEntry records that actor did action to a payee of tenant
+type AuditEntry struct {
+	TenantID   string
+	PayeeID    string
+	Actor      string
+	Action     AuditAction
+	OccurredAt time.Time
+}
+
+// AuditLog is the port to keep the audit trail of payees, entries are append only
+type AuditLog interface {
+	Record(ctx context.Context, entry AuditEntry) error
+	// List returns payee entries in the order they were recorded
+	List(ctx context.Context, tenantID string, payeeID string) ([]AuditEntry, error)
+}
+
+// recordAudit records action of ctx actor, it runs after the change was saved,
+// so an error means the change happened without its audit entry
+func recordAudit(ctx context.Context, audit AuditLog, tenantID, payeeID string, action AuditAction) error {
+	return audit.Record(ctx, AuditEntry{
+		TenantID:   tenantID,
+		PayeeID:    payeeID,
+		Actor:      ActorFromContext(ctx),
+		Action:     action,
+		OccurredAt: time.Now().UTC(),
+	})
+}
@@ -0,0 +1,86 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+)
+
+var ErrForbidden = errors.New("forbidden")
+
+// Role is what an actor is allowed to do in its tenant
+type Role string
+
+const (
+	// ViewerRole lists payees, with documents, pix keys and bank accounts masked
+	ViewerRole Role = "viewer"
+	// OperatorRole registers and edits payees
+	OperatorRole Role = "operator"
+	// ApproverRole validates payees registered by operators
+	ApproverRole Role = "approver"
+	// AdminRole has every permission
+	AdminRole Role = "admin"
+)
+
+// Permission is an operation guarded by roles
+type Permission string
+
+const (
+	ReadPermission     Permission = "read"
+	RegisterPermission Permission = "register"
+	// EditPermission also allows managing pix keys and sending email verifications
+	EditPermission     Permission = "edit"
+	ValidatePermission Permission = "validate"
+	DeletePermission   Permission = "delete"
+	ExportPermission   Permission = "export"
+	// AnonymizePermission allows scrubbing the personal data of a data subject, it can't be undone
+	AnonymizePermission Permission = "anonymize"
+	// ViewSensitiveDataPermission allows reading documents, pix keys and bank account numbers unmasked
+	ViewSensitiveDataPermission Permission = "view_sensitive_data"
+)
+
+// rolePermissions is the permission matrix, operators and approvers are kept apart,
+// so the same role can't register and validate a payee
+var rolePermissions = map[Role][]Permission{
+	ViewerRole:   {ReadPermission},
+	OperatorRole: {ReadPermission, ViewSensitiveDataPermission, RegisterPermission, EditPermission},
+	ApproverRole: {ReadPermission, ViewSensitiveDataPermission, ValidatePermission},
+	AdminRole: {
+		ReadPermission, ViewSensitiveDataPermission, RegisterPermission, EditPermission,
+		ValidatePermission, DeletePermission, ExportPermission, AnonymizePermission,
+	},
+}
+
+// ParseRole returns false when role is unknown
+func ParseRole(role string) (Role, bool) {
+	_, ok := rolePermissions[Role(role)]
+	return Role(role), ok
+}
+
+// HasPermission returns whether any role of ctx actor grants permission
+func HasPermission(ctx context.Context, permission Permission) bool {
+	for _, role := range RolesFromContext(ctx) {
+		if slices.Contains(rolePermissions[role], permission) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// maskSensitiveData reports whether documents, emails, contacts, pix keys and bank account numbers
+// must be masked for ctx actor, requested is the masking asked by client. Actors without
+// ViewSensitiveDataPermission, like viewers, always get them masked
+func maskSensitiveData(ctx context.Context, requested bool) bool {
+	return requested || !HasPermission(ctx, ViewSensitiveDataPermission)
+}
+
+// authorize returns ErrForbidden when no role of ctx actor grants permission
+func authorize(ctx context.Context, permission Permission) error {
+	if !HasPermission(ctx, permission) {
+		return fmt.Errorf("%w: %s permission is required", ErrForbidden, permission)
+	}
+
+	return nil
+}
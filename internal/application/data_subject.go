@@ -0,0 +1,129 @@
+package application
+
+import (
+	"context"
+	"time"
+
+	"github.com/italorfeitosa/payee-account-manager-api/internal/domain"
+)
+
+// DataSubjectPayee is a payee of data subject with its audit trail
+type DataSubjectPayee struct {
+	StoredPayee
+	// History is the audit trail of payee, in the order it was recorded
+	History []AuditEntry
+}
+
+type ExportDataSubjectOutput struct {
+	// CPF is the unformatted cpf of data subject
+	CPF        string
+	Payees     []DataSubjectPayee
+	ExportedAt time.Time
+}
+
+// ExportDataSubjectUseCase answers a LGPD access request, returning every payee of tenant,
+// in any status and deleted ones included, whose document or any CPF pix key is the data subject cpf
+type ExportDataSubjectUseCase struct {
+	repository PayeeRepository
+	audit      AuditLog
+}
+
+func NewExportDataSubjectUseCase(repository PayeeRepository, audit AuditLog) *ExportDataSubjectUseCase {
+	return &ExportDataSubjectUseCase{repository, audit}
+}
+
+// Execute exports payees of cpf, formatted or not. The cpf is not added to span, as it identifies the data subject
+func (uc *ExportDataSubjectUseCase) Execute(ctx context.Context, tenantID string, cpf string) (output ExportDataSubjectOutput, err error) {
+	ctx, span := startSpan(ctx, "ExportDataSubject", TenantIDAttribute.String(tenantID))
+	defer func() { EndSpan(span, err) }()
+
+	if err := authorize(ctx, ExportPermission); err != nil {
+		return ExportDataSubjectOutput{}, err
+	}
+
+	dataSubject, err := domain.NewCPF(cpf)
+	if err != nil {
+		return ExportDataSubjectOutput{}, err
+	}
+
+	stored, err := uc.repository.FindByDataSubject(ctx, tenantID, dataSubject.Value())
+	if err != nil {
+		return ExportDataSubjectOutput{}, err
+	}
+
+	payees := make([]DataSubjectPayee, 0, len(stored))
+	for _, payee := range stored {
+		history, err := uc.audit.List(ctx, tenantID, payee.Payee.ID())
+		if err != nil {
+			return ExportDataSubjectOutput{}, err
+		}
+
+		payees = append(payees, DataSubjectPayee{payee, history})
+	}
+
+	// recorded after history is read, so the export being answered is not part of it
+	for _, payee := range payees {
+		if err := recordAudit(ctx, uc.audit, tenantID, payee.Payee.ID(), DataSubjectExportedAction); err != nil {
+			return ExportDataSubjectOutput{}, err
+		}
+	}
+
+	return ExportDataSubjectOutput{
+		CPF:        dataSubject.Value(),
+		Payees:     payees,
+		ExportedAt: time.Now().UTC(),
+	}, nil
+}
+
+type AnonymizeDataSubjectOutput struct {
+	// PayeeIDs are the payees anonymized by the request, payees anonymized before are not included
+	PayeeIDs []string
+}
+
+// AnonymizeDataSubjectUseCase answers a LGPD erasure request, irreversibly scrubbing personal data
+// of every payee of tenant, deleted ones included, whose document or any CPF pix key is the data subject cpf
+type AnonymizeDataSubjectUseCase struct {
+	repository PayeeRepository
+	audit      AuditLog
+}
+
+func NewAnonymizeDataSubjectUseCase(repository PayeeRepository, audit AuditLog) *AnonymizeDataSubjectUseCase {
+	return &AnonymizeDataSubjectUseCase{repository, audit}
+}
+
+// Execute anonymizes payees of cpf, formatted or not. The cpf is not added to span, as it identifies the data subject
+func (uc *AnonymizeDataSubjectUseCase) Execute(ctx context.Context, tenantID string, cpf string) (output AnonymizeDataSubjectOutput, err error) {
+	ctx, span := startSpan(ctx, "AnonymizeDataSubject", TenantIDAttribute.String(tenantID))
+	defer func() { EndSpan(span, err) }()
+
+	if err := authorize(ctx, AnonymizePermission); err != nil {
+		return AnonymizeDataSubjectOutput{}, err
+	}
+
+	dataSubject, err := domain.NewCPF(cpf)
+	if err != nil {
+		return AnonymizeDataSubjectOutput{}, err
+	}
+
+	stored, err := uc.repository.FindByDataSubject(ctx, tenantID, dataSubject.Value())
+	if err != nil {
+		return AnonymizeDataSubjectOutput{}, err
+	}
+
+	payeeIDs := make([]string, 0, len(stored))
+	for _, payee := range stored {
+		payee.Payee.Anonymize()
+
+		if err := uc.repository.Save(ctx, tenantID, payee.Payee); err != nil {
+			return AnonymizeDataSubjectOutput{}, err
+		}
+
+		if err := recordAudit(ctx, uc.audit, tenantID, payee.Payee.ID(), PayeeAnonymizedAction); err != nil {
+			return AnonymizeDataSubjectOutput{}, err
+		}
+
+		payeeIDs = append(payeeIDs, payee.Payee.ID())
+	}
+
+	return AnonymizeDataSubjectOutput{PayeeIDs: payeeIDs}, nil
+}
@@ -0,0 +1,42 @@
+package application
+
+import (
+	"context"
+)
+
+// DeletePayeesUseCase soft deletes tenant payees
+type DeletePayeesUseCase struct {
+	repository PayeeRepository
+	audit      AuditLog
+}
+
+func NewDeletePayeesUseCase(repository PayeeRepository, audit AuditLog) *DeletePayeesUseCase {
+	return &DeletePayeesUseCase{repository, audit}
+}
+
+// Execute returns the ids of payees deleted, unknown and already deleted ids are ignored
+func (uc *DeletePayeesUseCase) Execute(ctx context.Context, tenantID string, payeeIDs []string) (deleted []string, err error) {
+	ctx, span := startSpan(ctx, "DeletePayees", TenantIDAttribute.String(tenantID), PayeeIDsAttribute.StringSlice(payeeIDs))
+	defer func() { EndSpan(span, err) }()
+
+	if err := authorize(ctx, DeletePermission); err != nil {
+		return nil, err
+	}
+
+	if len(payeeIDs) == 0 {
+		return nil, nil
+	}
+
+	deleted, err = uc.repository.Delete(ctx, tenantID, payeeIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, payeeID := range deleted {
+		if err := recordAudit(ctx, uc.audit, tenantID, payeeID, PayeeDeletedAction); err != nil {
+			return nil, err
+		}
+	}
+
+	return deleted, nil
+}
@@ -0,0 +1,3 @@
+// application package orchestrates the payee use cases, loading and persisting domain entities
+// through ports, such as PayeeRepository, which are implemented by infrastructure adapters
+package application
@@ -0,0 +1,17 @@
+package application
+
+import (
+	"context"
+	"errors"
+
+	"github.com/italorfeitosa/payee-account-manager-api/internal/domain"
+)
+
+var ErrDocumentRegistrationNotFound = errors.New("document registration not found")
+
+// DocumentStatusChecker is the port to query the cadastral status and official name
+// of CPF and CNPJ in Receita Federal
+type DocumentStatusChecker interface {
+	// Check returns ErrDocumentRegistrationNotFound when document is not registered
+	Check(ctx context.Context, document domain.Document) (domain.DocumentRegistration, error)
+}
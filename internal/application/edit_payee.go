@@ -0,0 +1,79 @@
+package application
+
+import (
+	"context"
+
+	"github.com/italorfeitosa/payee-account-manager-api/internal/domain"
+)
+
+type EditPayeeInput struct {
+	Name string
+	// TradeName is the nome fantasia, only allowed for CNPJ documents
+	TradeName string
+	// InscricaoEstadual is optional, only allowed for CNPJ documents
+	InscricaoEstadual *InscricaoEstadualInput
+	Document          string
+	Email             string
+	// Address is optional, nil means payee has no address
+	Address  *AddressInput
+	Contacts []ContactInput
+	// PixKeyType is detected from PixKey when omitted
+	PixKeyType string
+	PixKey     string
+}
+
+// EditPayeeUseCase updates payee details, following the status rules of domain.PayeeEntity
+type EditPayeeUseCase struct {
+	repository PayeeRepository
+	audit      AuditLog
+}
+
+func NewEditPayeeUseCase(repository PayeeRepository, audit AuditLog) *EditPayeeUseCase {
+	return &EditPayeeUseCase{repository, audit}
+}
+
+func (uc *EditPayeeUseCase) Execute(ctx context.Context, tenantID string, payeeID string, input EditPayeeInput) (err error) {
+	ctx, span := startSpan(ctx, "EditPayee", TenantIDAttribute.String(tenantID), PayeeIDAttribute.String(payeeID))
+	defer func() { EndSpan(span, err) }()
+
+	if err := authorize(ctx, EditPermission); err != nil {
+		return err
+	}
+
+	payee, err := uc.repository.FindByID(ctx, tenantID, payeeID)
+	if err != nil {
+		return err
+	}
+
+	// pix key is only editable in DRAFT status, so detection errors are irrelevant in other status
+	pixKeyType := input.PixKeyType
+	if payee.Status() == domain.PayeeDraftStatus {
+		pixKeyType, err = resolvePixKeyType(input.PixKeyType, input.PixKey)
+		if err != nil {
+			return err
+		}
+	}
+
+	opts, err := detailsOptions(input.TradeName, input.InscricaoEstadual, input.Address, input.Contacts)
+	if err != nil {
+		return err
+	}
+
+	err = payee.EditDetails(
+		input.Name,
+		input.Document,
+		pixKeyType,
+		input.PixKey,
+		input.Email,
+		opts...,
+	)
+	if err != nil {
+		return err
+	}
+
+	if err := uc.repository.Save(ctx, tenantID, payee); err != nil {
+		return err
+	}
+
+	return recordAudit(ctx, uc.audit, tenantID, payeeID, PayeeEditedAction)
+}
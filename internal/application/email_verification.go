@@ -0,0 +1,113 @@
+package application
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+
+	"github.com/italorfeitosa/payee-account-manager-api/internal/domain"
+)
+
+// SendEmailVerificationUseCase sends a verification token to payee email
+type SendEmailVerificationUseCase struct {
+	repository PayeeRepository
+	mailer     Mailer
+	tokens     *EmailVerificationTokens
+	audit      AuditLog
+	// confirmationURL is the page where payee confirms email, token is sent as query param
+	confirmationURL string
+}
+
+func NewSendEmailVerificationUseCase(
+	repository PayeeRepository,
+	mailer Mailer,
+	tokens *EmailVerificationTokens,
+	audit AuditLog,
+	confirmationURL string,
+) *SendEmailVerificationUseCase {
+	return &SendEmailVerificationUseCase{repository, mailer, tokens, audit, confirmationURL}
+}
+
+func (uc *SendEmailVerificationUseCase) Execute(ctx context.Context, tenantID string, payeeID string) (err error) {
+	ctx, span := startSpan(ctx, "SendEmailVerification", TenantIDAttribute.String(tenantID), PayeeIDAttribute.String(payeeID))
+	defer func() { EndSpan(span, err) }()
+
+	if err := authorize(ctx, EditPermission); err != nil {
+		return err
+	}
+
+	payee, err := uc.repository.FindByID(ctx, tenantID, payeeID)
+	if err != nil {
+		return err
+	}
+
+	if payee.Email() == "" {
+		return domain.ErrEmailNotSet
+	}
+
+	token, err := uc.tokens.Issue(tenantID, payee.ID(), payee.Email())
+	if err != nil {
+		return err
+	}
+
+	link := uc.confirmationURL + "?" + url.Values{"token": {token}}.Encode()
+
+	err = uc.mailer.Send(ctx, EmailMessage{
+		To:      payee.Email(),
+		Subject: "Confirm your email",
+		Body: fmt.Sprintf(
+			"Hello %s,\n\nconfirm your email address in the link below, it expires in %s:\n\n%s\n",
+			payee.Name(), uc.tokens.ttl, link,
+		),
+	})
+	if err != nil {
+		return err
+	}
+
+	return recordAudit(ctx, uc.audit, tenantID, payeeID, EmailVerificationSentAction)
+}
+
+// ConfirmEmailUseCase marks payee email as verified from a verification token
+type ConfirmEmailUseCase struct {
+	repository PayeeRepository
+	tokens     *EmailVerificationTokens
+	audit      AuditLog
+}
+
+func NewConfirmEmailUseCase(repository PayeeRepository, tokens *EmailVerificationTokens, audit AuditLog) *ConfirmEmailUseCase {
+	return &ConfirmEmailUseCase{repository, tokens, audit}
+}
+
+func (uc *ConfirmEmailUseCase) Execute(ctx context.Context, token string) (err error) {
+	ctx, span := startSpan(ctx, "ConfirmEmail")
+	defer func() { EndSpan(span, err) }()
+
+	claims, err := uc.tokens.Parse(token)
+	if err != nil {
+		return err
+	}
+
+	// confirmation is not authenticated, the payee who owns the token is the actor
+	ctx = WithActor(ctx, payeeActor(claims.PayeeID))
+
+	span.SetAttributes(
+		TenantIDAttribute.String(claims.TenantID),
+		PayeeIDAttribute.String(claims.PayeeID),
+		ActorAttribute.String(payeeActor(claims.PayeeID)),
+	)
+
+	payee, err := uc.repository.FindByID(ctx, claims.TenantID, claims.PayeeID)
+	if err != nil {
+		return err
+	}
+
+	if err := payee.VerifyEmail(claims.Email); err != nil {
+		return err
+	}
+
+	if err := uc.repository.Save(ctx, claims.TenantID, payee); err != nil {
+		return err
+	}
+
+	return recordAudit(ctx, uc.audit, claims.TenantID, claims.PayeeID, EmailConfirmedAction)
+}
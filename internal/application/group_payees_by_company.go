@@ -0,0 +1,103 @@
+package application
+
+import (
+	"cmp"
+	"context"
+	"slices"
+
+	"github.com/italorfeitosa/payee-account-manager-api/internal/domain"
+)
+
+// CompanyBranch is a payee which document is a CNPJ of company
+type CompanyBranch struct {
+	Payee *domain.PayeeEntity
+	CNPJ  domain.CNPJ
+}
+
+// CompanyGroup holds the payees that share the same CNPJ root, headquarters first then by branch order
+type CompanyGroup struct {
+	CNPJRoot string
+	Branches []CompanyBranch
+}
+
+// Headquarters returns the payee of company headquarters, nil if it is not registered
+func (g CompanyGroup) Headquarters() *domain.PayeeEntity {
+	for _, branch := range g.Branches {
+		if branch.CNPJ.IsHeadquarters() {
+			return branch.Payee
+		}
+	}
+
+	return nil
+}
+
+type GroupPayeesByCompanyOutput struct {
+	Companies []CompanyGroup
+	// Masked tells CNPJs must be returned masked, as actor can't view sensitive data
+	Masked bool
+}
+
+// GroupPayeesByCompanyUseCase groups the tenant payees with CNPJ by company root
+type GroupPayeesByCompanyUseCase struct {
+	repository PayeeRepository
+}
+
+func NewGroupPayeesByCompanyUseCase(repository PayeeRepository) *GroupPayeesByCompanyUseCase {
+	return &GroupPayeesByCompanyUseCase{repository}
+}
+
+// Execute returns company groups ordered by CNPJ root
+func (uc *GroupPayeesByCompanyUseCase) Execute(ctx context.Context, tenantID string) (output GroupPayeesByCompanyOutput, err error) {
+	ctx, span := startSpan(ctx, "GroupPayeesByCompany", TenantIDAttribute.String(tenantID))
+	defer func() { EndSpan(span, err) }()
+
+	if err := authorize(ctx, ReadPermission); err != nil {
+		return GroupPayeesByCompanyOutput{}, err
+	}
+
+	groups := make(map[string]*CompanyGroup)
+
+	query := ListPayeesQuery{Page: 1, Size: MaxPageSize, PersonKind: domain.LegalPersonKind}
+	for {
+		payees, total, err := uc.repository.List(ctx, tenantID, query)
+		if err != nil {
+			return GroupPayeesByCompanyOutput{}, err
+		}
+
+		for _, payee := range payees {
+			cnpj, ok := payee.Document().(domain.CNPJ)
+			if !ok {
+				continue
+			}
+
+			group, ok := groups[cnpj.Root()]
+			if !ok {
+				group = &CompanyGroup{CNPJRoot: cnpj.Root()}
+				groups[cnpj.Root()] = group
+			}
+
+			group.Branches = append(group.Branches, CompanyBranch{Payee: payee, CNPJ: cnpj})
+		}
+
+		if query.Offset()+len(payees) >= total || len(payees) == 0 {
+			break
+		}
+
+		query.Page++
+	}
+
+	result := make([]CompanyGroup, 0, len(groups))
+	for _, group := range groups {
+		slices.SortFunc(group.Branches, func(a, b CompanyBranch) int {
+			return cmp.Compare(a.CNPJ.BranchOrder(), b.CNPJ.BranchOrder())
+		})
+
+		result = append(result, *group)
+	}
+
+	slices.SortFunc(result, func(a, b CompanyGroup) int {
+		return cmp.Compare(a.CNPJRoot, b.CNPJRoot)
+	})
+
+	return GroupPayeesByCompanyOutput{Companies: result, Masked: maskSensitiveData(ctx, false)}, nil
+}
@@ -0,0 +1,95 @@
+package application
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+)
+
+// IdempotencyKeyMaxLength is the max size of idempotency keys, in bytes
+const IdempotencyKeyMaxLength = 255
+
+var (
+	ErrInvalidIdempotencyKey       = errors.New("idempotency key must have at most 255 bytes")
+	ErrIdempotencyKeyReused        = errors.New("idempotency key was already used with a different request")
+	ErrIdempotentRequestInProgress = errors.New("a request with this idempotency key is in progress")
+)
+
+// IdempotencyRecord is the stored result of an idempotent registration
+type IdempotencyRecord struct {
+	// Fingerprint identifies the input of the first request with the key
+	Fingerprint string
+	// PayeeID is empty while first request is in progress
+	PayeeID string
+}
+
+// IdempotencyStore is the port to keep idempotency keys, scoped by tenant, until they expire
+type IdempotencyStore interface {
+	// Reserve stores key with fingerprint and returns reserved true when key is new, expired or its
+	// reservation is stale (not completed within a lease), otherwise returns the stored record without changing it
+	Reserve(ctx context.Context, tenantID, key, fingerprint string) (record IdempotencyRecord, reserved bool, err error)
+	// Complete stores the id of payee registered by the request of key, keeping it until ttl
+	Complete(ctx context.Context, tenantID, key, payeeID string) error
+	// Release removes the reservation of key, so a failed request can be retried with the same key
+	Release(ctx context.Context, tenantID, key string) error
+}
+
+// registerIdempotent registers payee once per idempotency key, retries with same input return the first payee
+func (uc *RegisterPayeeUseCase) registerIdempotent(ctx context.Context, tenantID string, input RegisterPayeeInput) (RegisterPayeeOutput, error) {
+	if len(input.IdempotencyKey) > IdempotencyKeyMaxLength {
+		return RegisterPayeeOutput{}, ErrInvalidIdempotencyKey
+	}
+
+	fingerprint, err := registrationFingerprint(input)
+	if err != nil {
+		return RegisterPayeeOutput{}, err
+	}
+
+	record, reserved, err := uc.idempotency.Reserve(ctx, tenantID, input.IdempotencyKey, fingerprint)
+	if err != nil {
+		return RegisterPayeeOutput{}, err
+	}
+
+	if !reserved {
+		switch {
+		case record.Fingerprint != fingerprint:
+			return RegisterPayeeOutput{}, ErrIdempotencyKeyReused
+		case record.PayeeID == "":
+			return RegisterPayeeOutput{}, ErrIdempotentRequestInProgress
+		default:
+			return RegisterPayeeOutput{ID: record.PayeeID, Replayed: true}, nil
+		}
+	}
+
+	id, err := uc.register(ctx, tenantID, input)
+	if err != nil {
+		// only registered payees are replayed, failures can be fixed and retried with the same key
+		if releaseErr := uc.idempotency.Release(ctx, tenantID, input.IdempotencyKey); releaseErr != nil {
+			return RegisterPayeeOutput{}, errors.Join(err, releaseErr)
+		}
+
+		return RegisterPayeeOutput{}, err
+	}
+
+	if err := uc.idempotency.Complete(ctx, tenantID, input.IdempotencyKey, id); err != nil {
+		return RegisterPayeeOutput{}, err
+	}
+
+	return RegisterPayeeOutput{ID: id}, nil
+}
+
+// registrationFingerprint hashes every input value but the idempotency key
+func registrationFingerprint(input RegisterPayeeInput) (string, error) {
+	input.IdempotencyKey = ""
+
+	encoded, err := json.Marshal(input)
+	if err != nil {
+		return "", err
+	}
+
+	sum := sha256.Sum256(encoded)
+
+	return hex.EncodeToString(sum[:]), nil
+}
@@ -0,0 +1,102 @@
+package application
+
+import (
+	"context"
+
+	"github.com/italorfeitosa/payee-account-manager-api/internal/domain"
+)
+
+const (
+	DefaultPageSize = 10
+	MaxPageSize     = 100
+)
+
+type ListPayeesInput struct {
+	Page   int
+	Size   int
+	Search string
+	// PersonKind is NATURAL or LEGAL, empty means any
+	PersonKind string
+	// CNPJRoot is the 8 characters root of CNPJ, formatted or not, empty means any
+	CNPJRoot string
+	// Masked asks sensitive data to be masked, it is masked anyway when actor can't view it
+	Masked bool
+}
+
+type ListPayeesOutput struct {
+	Payees     []*domain.PayeeEntity
+	TotalItems int
+	TotalPages int
+	Page       int
+	PageSize   int
+	// Masked tells sensitive data of payees must be returned masked
+	Masked bool
+}
+
+// ListPayeesUseCase returns a page of tenant payees, optionally filtered by search term
+type ListPayeesUseCase struct {
+	repository PayeeRepository
+}
+
+func NewListPayeesUseCase(repository PayeeRepository) *ListPayeesUseCase {
+	return &ListPayeesUseCase{repository}
+}
+
+func (uc *ListPayeesUseCase) Execute(ctx context.Context, tenantID string, input ListPayeesInput) (output ListPayeesOutput, err error) {
+	ctx, span := startSpan(ctx, "ListPayees", TenantIDAttribute.String(tenantID))
+	defer func() { EndSpan(span, err) }()
+
+	if err := authorize(ctx, ReadPermission); err != nil {
+		return ListPayeesOutput{}, err
+	}
+
+	query := ListPayeesQuery{
+		Page:   input.Page,
+		Size:   input.Size,
+		Search: input.Search,
+	}
+
+	if input.PersonKind != "" {
+		personKind, err := domain.NewPersonKind(input.PersonKind)
+		if err != nil {
+			return ListPayeesOutput{}, err
+		}
+
+		query.PersonKind = personKind
+	}
+
+	if input.CNPJRoot != "" {
+		cnpjRoot, err := domain.NewCNPJRoot(input.CNPJRoot)
+		if err != nil {
+			return ListPayeesOutput{}, err
+		}
+
+		query.CNPJRoot = cnpjRoot
+	}
+
+	if query.Page < 1 {
+		query.Page = 1
+	}
+
+	if query.Size < 1 {
+		query.Size = DefaultPageSize
+	}
+
+	if query.Size > MaxPageSize {
+		query.Size = MaxPageSize
+	}
+
+	payees, total, err := uc.repository.List(ctx, tenantID, query)
+	if err != nil {
+		return ListPayeesOutput{}, err
+	}
+
+	return ListPayeesOutput{
+		Payees:     payees,
+		TotalItems: total,
+		TotalPages: (total + query.Size - 1) / query.Size,
+		Page:       query.Page,
+		PageSize:   query.Size,
+		Masked:     maskSensitiveData(ctx, input.Masked),
+	}, nil
+}
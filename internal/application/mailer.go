@@ -0,0 +1,15 @@
+package application
+
+import "context"
+
+// EmailMessage is a plain text email
+type EmailMessage struct {
+	To      string
+	Subject string
+	Body    string
+}
+
+// Mailer is the port to deliver emails to payees
+type Mailer interface {
+	Send(ctx context.Context, message EmailMessage) error
+}
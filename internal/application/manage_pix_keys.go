@@ -0,0 +1,128 @@
+package application
+
+import (
+	"context"
+)
+
+type PixKeyInput struct {
+	// PixKeyType is detected from PixKey when omitted
+	PixKeyType string
+	PixKey     string
+}
+
+// AddPixKeyUseCase appends a non primary pix key to a DRAFT payee
+type AddPixKeyUseCase struct {
+	repository PayeeRepository
+	audit      AuditLog
+}
+
+func NewAddPixKeyUseCase(repository PayeeRepository, audit AuditLog) *AddPixKeyUseCase {
+	return &AddPixKeyUseCase{repository, audit}
+}
+
+func (uc *AddPixKeyUseCase) Execute(ctx context.Context, tenantID string, payeeID string, input PixKeyInput) (err error) {
+	ctx, span := startSpan(ctx, "AddPixKey", TenantIDAttribute.String(tenantID), PayeeIDAttribute.String(payeeID))
+	defer func() { EndSpan(span, err) }()
+
+	if err := authorize(ctx, EditPermission); err != nil {
+		return err
+	}
+
+	pixKeyType, err := resolvePixKeyType(input.PixKeyType, input.PixKey)
+	if err != nil {
+		return err
+	}
+
+	payee, err := uc.repository.FindByID(ctx, tenantID, payeeID)
+	if err != nil {
+		return err
+	}
+
+	if err := payee.AddPixKey(pixKeyType, input.PixKey); err != nil {
+		return err
+	}
+
+	if err := uc.repository.Save(ctx, tenantID, payee); err != nil {
+		return err
+	}
+
+	return recordAudit(ctx, uc.audit, tenantID, payeeID, PixKeyAddedAction)
+}
+
+// RemovePixKeyUseCase removes a non primary pix key from a DRAFT payee
+type RemovePixKeyUseCase struct {
+	repository PayeeRepository
+	audit      AuditLog
+}
+
+func NewRemovePixKeyUseCase(repository PayeeRepository, audit AuditLog) *RemovePixKeyUseCase {
+	return &RemovePixKeyUseCase{repository, audit}
+}
+
+func (uc *RemovePixKeyUseCase) Execute(ctx context.Context, tenantID string, payeeID string, input PixKeyInput) (err error) {
+	ctx, span := startSpan(ctx, "RemovePixKey", TenantIDAttribute.String(tenantID), PayeeIDAttribute.String(payeeID))
+	defer func() { EndSpan(span, err) }()
+
+	if err := authorize(ctx, EditPermission); err != nil {
+		return err
+	}
+
+	pixKeyType, err := resolvePixKeyType(input.PixKeyType, input.PixKey)
+	if err != nil {
+		return err
+	}
+
+	payee, err := uc.repository.FindByID(ctx, tenantID, payeeID)
+	if err != nil {
+		return err
+	}
+
+	if err := payee.RemovePixKey(pixKeyType, input.PixKey); err != nil {
+		return err
+	}
+
+	if err := uc.repository.Save(ctx, tenantID, payee); err != nil {
+		return err
+	}
+
+	return recordAudit(ctx, uc.audit, tenantID, payeeID, PixKeyRemovedAction)
+}
+
+// SetPrimaryPixKeyUseCase turns one of DRAFT payee pix keys into the primary
+type SetPrimaryPixKeyUseCase struct {
+	repository PayeeRepository
+	audit      AuditLog
+}
+
+func NewSetPrimaryPixKeyUseCase(repository PayeeRepository, audit AuditLog) *SetPrimaryPixKeyUseCase {
+	return &SetPrimaryPixKeyUseCase{repository, audit}
+}
+
+func (uc *SetPrimaryPixKeyUseCase) Execute(ctx context.Context, tenantID string, payeeID string, input PixKeyInput) (err error) {
+	ctx, span := startSpan(ctx, "SetPrimaryPixKey", TenantIDAttribute.String(tenantID), PayeeIDAttribute.String(payeeID))
+	defer func() { EndSpan(span, err) }()
+
+	if err := authorize(ctx, EditPermission); err != nil {
+		return err
+	}
+
+	pixKeyType, err := resolvePixKeyType(input.PixKeyType, input.PixKey)
+	if err != nil {
+		return err
+	}
+
+	payee, err := uc.repository.FindByID(ctx, tenantID, payeeID)
+	if err != nil {
+		return err
+	}
+
+	if err := payee.SetPrimaryPixKey(pixKeyType, input.PixKey); err != nil {
+		return err
+	}
+
+	if err := uc.repository.Save(ctx, tenantID, payee); err != nil {
+		return err
+	}
+
+	return recordAudit(ctx, uc.audit, tenantID, payeeID, PrimaryPixKeySetAction)
+}
@@ -0,0 +1,79 @@
+package application
+
+import "github.com/italorfeitosa/payee-account-manager-api/internal/domain"
+
+type AddressInput struct {
+	Street       string
+	Number       string
+	Complement   string
+	Neighborhood string
+	City         string
+	UF           string
+	CEP          string
+}
+
+type InscricaoEstadualInput struct {
+	// UF is the federative unit that issued the state registration
+	UF    string
+	Value string
+}
+
+type ContactInput struct {
+	// Type is EMAIL or PHONE
+	Type    string
+	Value   string
+	Primary bool
+}
+
+// detailsOptions builds the optional payee details of register and edit inputs,
+// a nil inscricao estadual, a nil address and empty contacts clear the payee values
+func detailsOptions(
+	tradeName string,
+	inscricaoEstadual *InscricaoEstadualInput,
+	address *AddressInput,
+	contacts []ContactInput,
+) ([]domain.DetailsOption, error) {
+	opts := []domain.DetailsOption{domain.WithTradeName(tradeName)}
+
+	if inscricaoEstadual != nil {
+		ie, err := domain.NewInscricaoEstadual(inscricaoEstadual.UF, inscricaoEstadual.Value)
+		if err != nil {
+			return nil, err
+		}
+
+		opts = append(opts, domain.WithInscricaoEstadual(&ie))
+	} else {
+		opts = append(opts, domain.WithInscricaoEstadual(nil))
+	}
+
+	if address != nil {
+		payeeAddress, err := domain.NewAddress(
+			address.Street,
+			address.Number,
+			address.Complement,
+			address.Neighborhood,
+			address.City,
+			address.UF,
+			address.CEP,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		opts = append(opts, domain.WithAddress(&payeeAddress))
+	} else {
+		opts = append(opts, domain.WithAddress(nil))
+	}
+
+	payeeContacts := make([]domain.Contact, 0, len(contacts))
+	for _, contact := range contacts {
+		payeeContact, err := domain.NewContact(contact.Type, contact.Value, contact.Primary)
+		if err != nil {
+			return nil, err
+		}
+
+		payeeContacts = append(payeeContacts, payeeContact)
+	}
+
+	return append(opts, domain.WithContacts(payeeContacts)), nil
+}
@@ -0,0 +1,88 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/italorfeitosa/payee-account-manager-api/internal/domain"
+)
+
+var (
+	ErrPayeeNotFound = errors.New("payee not found")
+	ErrPayeeConflict = errors.New("payee conflict")
+)
+
+const (
+	DocumentConflictField = "cpf_cnpj"
+	PixKeyConflictField   = "pix_key"
+)
+
+// ConflictError is returned when a payee document or pix key already belongs
+// to another payee of the same tenant, it matches ErrPayeeConflict with errors.Is
+type ConflictError struct {
+	// Field is DocumentConflictField or PixKeyConflictField
+	Field string
+	// Value is the conflicting value, pix keys are prefixed by type (Ex: EMAIL:italo@feitosa.com)
+	Value string
+	// ExistingPayeeID is the id of payee that already owns the value
+	ExistingPayeeID string
+}
+
+func (e *ConflictError) Error() string {
+	return fmt.Sprintf("%s: %s %s already belongs to payee %s", ErrPayeeConflict, e.Field, e.Value, e.ExistingPayeeID)
+}
+
+func (e *ConflictError) Is(target error) bool {
+	return target == ErrPayeeConflict
+}
+
+// ListPayeesQuery holds pagination and search criteria to list payees
+type ListPayeesQuery struct {
+	// Page starts at 1
+	Page int
+	// Size is the max amount of payees per page
+	Size int
+	// Search matches by name, trade name, cpf_cnpj, branch_number, account_number, status, pix_key_type or pix_key.
+	// Names are matched ignoring case and diacritics, ranked by exact, prefix and then contains matches
+	Search string
+	// PersonKind filters payees by document person kind, empty means any
+	PersonKind domain.PersonKind
+	// CNPJRoot filters payees by the 8 characters root of CNPJ, empty means any
+	CNPJRoot string
+}
+
+// Offset returns how many payees must be skipped to reach the page
+func (q ListPayeesQuery) Offset() int {
+	return (q.Page - 1) * q.Size
+}
+
+// StoredPayee is a payee as stored, deleted payees included
+type StoredPayee struct {
+	Payee *domain.PayeeEntity
+	// DeletedAt is nil while payee is not deleted
+	DeletedAt *time.Time
+}
+
+// PayeeRepository is the port to persist payees, every operation is scoped by tenant
+type PayeeRepository interface {
+	// Save inserts or updates a payee, returns *ConflictError when document or any pix key
+	// already belongs to another payee of tenant, deleted payees are not considered
+	Save(ctx context.Context, tenantID string, payee *domain.PayeeEntity) error
+	// FindByID returns ErrPayeeNotFound if payee does not exist or is deleted
+	FindByID(ctx context.Context, tenantID string, payeeID string) (*domain.PayeeEntity, error)
+	// List returns the payees of the page and the total amount of payees matching query
+	List(ctx context.Context, tenantID string, query ListPayeesQuery) ([]*domain.PayeeEntity, int, error)
+	// Delete marks payees as deleted and returns the ids it deleted, unknown and already deleted ids are ignored
+	Delete(ctx context.Context, tenantID string, payeeIDs []string) (deleted []string, err error)
+	// FindByDataSubject returns every payee of tenant, deleted ones included, whose document
+	// or any CPF pix key is cpf, in registration order. cpf is unformatted
+	FindByDataSubject(ctx context.Context, tenantID string, cpf string) ([]StoredPayee, error)
+	// SaveWithinQuota inserts a new payee as Save does, unless tenant already created limit payees since
+	// the given time, deleted ones included. Counting and inserting are atomic, so concurrent registrations
+	// can't go past limit. Returns ErrRegistrationQuotaExceeded when limit is reached
+	SaveWithinQuota(ctx context.Context, tenantID string, payee *domain.PayeeEntity, since time.Time, limit int) error
+	// Ping checks the storage can serve requests, it is the readiness check of repository
+	Ping(ctx context.Context) error
+}
@@ -0,0 +1,138 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/italorfeitosa/payee-account-manager-api/internal/domain"
+	"go.opentelemetry.io/otel/trace"
+)
+
+type RegisterPayeeInput struct {
+	Name string
+	// TradeName is the nome fantasia, only allowed for CNPJ documents
+	TradeName string
+	// InscricaoEstadual is optional, only allowed for CNPJ documents
+	InscricaoEstadual *InscricaoEstadualInput
+	Document          string
+	Email             string
+	// Address is optional, nil means payee has no address
+	Address  *AddressInput
+	Contacts []ContactInput
+	// PixKeyType is detected from PixKey when omitted
+	PixKeyType string
+	PixKey     string
+	// IdempotencyKey is optional, retries with the same key and input return the first registered payee
+	IdempotencyKey string
+}
+
+type RegisterPayeeOutput struct {
+	ID string
+	// Replayed is true when payee was registered by a previous request with the same idempotency key
+	Replayed bool
+}
+
+// RegisterPayeeUseCase creates a new payee in DRAFT status
+type RegisterPayeeUseCase struct {
+	repository  PayeeRepository
+	quota       RegistrationQuota
+	idempotency IdempotencyStore
+	audit       AuditLog
+}
+
+// NewRegisterPayeeUseCase returns the use case, a nil quota means tenants have no daily limit
+// and a nil idempotency store means idempotency keys are ignored
+func NewRegisterPayeeUseCase(
+	repository PayeeRepository,
+	quota RegistrationQuota,
+	idempotency IdempotencyStore,
+	audit AuditLog,
+) *RegisterPayeeUseCase {
+	return &RegisterPayeeUseCase{repository, quota, idempotency, audit}
+}
+
+// Execute returns the id of registered payee
+func (uc *RegisterPayeeUseCase) Execute(ctx context.Context, tenantID string, input RegisterPayeeInput) (output RegisterPayeeOutput, err error) {
+	ctx, span := startSpan(ctx, "RegisterPayee", TenantIDAttribute.String(tenantID))
+	defer func() { EndSpan(span, err) }()
+
+	if err := authorize(ctx, RegisterPermission); err != nil {
+		return RegisterPayeeOutput{}, err
+	}
+
+	if input.IdempotencyKey != "" && uc.idempotency != nil {
+		return uc.registerIdempotent(ctx, tenantID, input)
+	}
+
+	id, err := uc.register(ctx, tenantID, input)
+
+	return RegisterPayeeOutput{ID: id}, err
+}
+
+func (uc *RegisterPayeeUseCase) register(ctx context.Context, tenantID string, input RegisterPayeeInput) (string, error) {
+	pixKeyType, err := resolvePixKeyType(input.PixKeyType, input.PixKey)
+	if err != nil {
+		return "", err
+	}
+
+	opts, err := detailsOptions(input.TradeName, input.InscricaoEstadual, input.Address, input.Contacts)
+	if err != nil {
+		return "", err
+	}
+
+	payee, err := domain.CreatePayee(
+		input.Name,
+		input.Document,
+		pixKeyType,
+		input.PixKey,
+		input.Email,
+		opts...,
+	)
+	if err != nil {
+		return "", err
+	}
+
+	trace.SpanFromContext(ctx).SetAttributes(PayeeIDAttribute.String(payee.ID()))
+
+	if err := uc.save(ctx, tenantID, payee); err != nil {
+		return "", err
+	}
+
+	if err := recordAudit(ctx, uc.audit, tenantID, payee.ID(), PayeeRegisteredAction); err != nil {
+		return "", err
+	}
+
+	return payee.ID(), nil
+}
+
+// save inserts payee within tenant daily quota, returns *QuotaExceededError when tenant
+// already registered its quota, deleted payees are counted so deleting them does not restore quota
+func (uc *RegisterPayeeUseCase) save(ctx context.Context, tenantID string, payee *domain.PayeeEntity) error {
+	limit := 0
+	if uc.quota != nil {
+		limit = uc.quota(tenantID)
+	}
+
+	if limit <= 0 {
+		return uc.repository.Save(ctx, tenantID, payee)
+	}
+
+	startOfDay := time.Now().UTC().Truncate(24 * time.Hour)
+
+	err := uc.repository.SaveWithinQuota(ctx, tenantID, payee, startOfDay, limit)
+	if errors.Is(err, ErrRegistrationQuotaExceeded) {
+		return &QuotaExceededError{Limit: limit, ResetAt: startOfDay.Add(24 * time.Hour)}
+	}
+
+	return err
+}
+
+// resolvePixKeyType returns pixKeyType, or detects it from pixKey when omitted
+func resolvePixKeyType(pixKeyType, pixKey string) (string, error) {
+	if pixKeyType != "" {
+		return pixKeyType, nil
+	}
+
+	return domain.DetectPixKeyType(pixKey)
+}
@@ -0,0 +1,28 @@
+package application
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+var ErrRegistrationQuotaExceeded = errors.New("daily payee registration quota exceeded")
+
+// RegistrationQuota returns how many payees a tenant can register per day (UTC), zero means unlimited
+type RegistrationQuota func(tenantID string) int
+
+// QuotaExceededError is returned when tenant already registered its daily quota of payees,
+// it matches ErrRegistrationQuotaExceeded with errors.Is
+type QuotaExceededError struct {
+	Limit int
+	// ResetAt is when tenant can register payees again, the start of next day
+	ResetAt time.Time
+}
+
+func (e *QuotaExceededError) Error() string {
+	return fmt.Sprintf("%s: limit of %d payees per day, resets at %s", ErrRegistrationQuotaExceeded, e.Limit, e.ResetAt.Format(time.RFC3339))
+}
+
+func (e *QuotaExceededError) Is(target error) bool {
+	return target == ErrRegistrationQuotaExceeded
+}
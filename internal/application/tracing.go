@@ -0,0 +1,43 @@
+package application
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// TracerName is the instrumentation name of use case spans
+const TracerName = "github.com/italorfeitosa/payee-account-manager-api/internal/application"
+
+// Span attributes shared by http, use case and repository spans
+const (
+	TenantIDAttribute = attribute.Key("tenant.id")
+	PayeeIDAttribute  = attribute.Key("payee.id")
+	PayeeIDsAttribute = attribute.Key("payee.ids")
+	ActorAttribute    = attribute.Key("enduser.id")
+)
+
+var tracer = otel.Tracer(TracerName)
+
+// startSpan starts the span of a use case, name is the use case without suffix (Ex: RegisterPayee),
+// the actor of ctx, when there is one, is added to attrs
+func startSpan(ctx context.Context, name string, attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+	if actor := ActorFromContext(ctx); actor != "" {
+		attrs = append(attrs, ActorAttribute.String(actor))
+	}
+
+	return tracer.Start(ctx, name, trace.WithAttributes(attrs...))
+}
+
+// EndSpan records err, when not nil, as the span status and ends span
+func EndSpan(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+
+	span.End()
+}
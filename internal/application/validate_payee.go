@@ -0,0 +1,64 @@
+package application
+
+import (
+	"context"
+
+	"github.com/italorfeitosa/payee-account-manager-api/internal/domain"
+)
+
+type ValidatePayeeInput struct {
+	BankAccount domain.BankAccount
+}
+
+// ValidatePayeeUseCase turns a DRAFT payee into VALID
+type ValidatePayeeUseCase struct {
+	repository PayeeRepository
+	checker    DocumentStatusChecker
+	audit      AuditLog
+}
+
+// NewValidatePayeeUseCase returns the use case, checker is optional, when it is nil
+// the document registration in Receita Federal is not checked
+func NewValidatePayeeUseCase(repository PayeeRepository, checker DocumentStatusChecker, audit AuditLog) *ValidatePayeeUseCase {
+	return &ValidatePayeeUseCase{repository, checker, audit}
+}
+
+func (uc *ValidatePayeeUseCase) Execute(ctx context.Context, tenantID string, payeeID string, input ValidatePayeeInput) (err error) {
+	ctx, span := startSpan(ctx, "ValidatePayee", TenantIDAttribute.String(tenantID), PayeeIDAttribute.String(payeeID))
+	defer func() { EndSpan(span, err) }()
+
+	if err := authorize(ctx, ValidatePermission); err != nil {
+		return err
+	}
+
+	payee, err := uc.repository.FindByID(ctx, tenantID, payeeID)
+	if err != nil {
+		return err
+	}
+
+	// status is checked before Receita Federal, so a payee that can't be validated makes no external call
+	if err := payee.CanValidate(input.BankAccount); err != nil {
+		return err
+	}
+
+	if uc.checker != nil {
+		registration, err := uc.checker.Check(ctx, payee.Document())
+		if err != nil {
+			return err
+		}
+
+		if err := registration.Verify(payee.Name()); err != nil {
+			return err
+		}
+	}
+
+	if err := payee.Validate(input.BankAccount); err != nil {
+		return err
+	}
+
+	if err := uc.repository.Save(ctx, tenantID, payee); err != nil {
+		return err
+	}
+
+	return recordAudit(ctx, uc.audit, tenantID, payeeID, PayeeValidatedAction)
+}
@@ -0,0 +1,466 @@
+package config
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"time"
+)
+
+// Duration is a time.Duration decoded from json strings like "5s"
+type Duration struct {
+	time.Duration
+}
+
+func (d *Duration) UnmarshalJSON(data []byte) error {
+	var v string
+	if err := json.Unmarshal(data, &v); err != nil {
+		return fmt.Errorf("duration must be a string like \"5s\": %w", err)
+	}
+
+	parsed, err := time.ParseDuration(v)
+	if err != nil {
+		return err
+	}
+
+	d.Duration = parsed
+
+	return nil
+}
+
+type HTTPConfig struct {
+	Addr         string   `json:"addr"`
+	ReadTimeout  Duration `json:"read_timeout"`
+	WriteTimeout Duration `json:"write_timeout"`
+	// MetricsAddr is the internal listen address of /metrics, apart from Addr because metrics carry tenant ids
+	MetricsAddr string `json:"metrics_addr"`
+	// DrainDelay is how long /readyz reports not ready before server stops accepting connections,
+	// so load balancers see it and stop routing requests, zero stops right away
+	DrainDelay Duration `json:"drain_delay"`
+	// ShutdownTimeout is how long in-flight requests are drained on shutdown
+	ShutdownTimeout Duration `json:"shutdown_timeout"`
+}
+
+// SMTPConfig configures email delivery, when Addr is empty emails are discarded
+type SMTPConfig struct {
+	Addr     string `json:"addr"`
+	From     string `json:"from"`
+	Username string `json:"username"`
+	Password string `json:"password"`
+	// Timeout is the max time to deliver an email, from dialing to closing the session
+	Timeout Duration `json:"timeout"`
+}
+
+type EmailVerificationConfig struct {
+	// Secret signs verification tokens, at least 32 bytes
+	Secret string   `json:"secret"`
+	TTL    Duration `json:"ttl"`
+	// ConfirmationURL is the page where payees confirm their email
+	ConfirmationURL string `json:"confirmation_url"`
+}
+
+// ReceitaConfig configures the document status checker, when StatusFile is empty
+// documents are not checked in Receita Federal
+type ReceitaConfig struct {
+	StatusFile string `json:"status_file"`
+}
+
+// Tracing exporters
+const (
+	NoneTracingExporter   = "none"
+	StdoutTracingExporter = "stdout"
+)
+
+// TracingConfig configures where spans are exported, "none" disables tracing
+type TracingConfig struct {
+	Exporter string `json:"exporter"`
+}
+
+// TenantLimits are the request rates and daily registration quota of a tenant,
+// zero reads or writes per second turns off that rate limit
+type TenantLimits struct {
+	ReadsPerSecond  float64 `json:"reads_per_second"`
+	ReadBurst       int     `json:"read_burst"`
+	WritesPerSecond float64 `json:"writes_per_second"`
+	WriteBurst      int     `json:"write_burst"`
+	// DailyRegistrations is how many payees tenant can register per day, zero means unlimited
+	DailyRegistrations int `json:"daily_registrations"`
+}
+
+// TenantLimitsOverride are the limits of a specific tenant, omitted (nil) values inherit the default limits,
+// so an explicit zero turns off a rate limit or makes daily registrations unlimited
+type TenantLimitsOverride struct {
+	ReadsPerSecond     *float64 `json:"reads_per_second"`
+	ReadBurst          *int     `json:"read_burst"`
+	WritesPerSecond    *float64 `json:"writes_per_second"`
+	WriteBurst         *int     `json:"write_burst"`
+	DailyRegistrations *int     `json:"daily_registrations"`
+}
+
+// RateLimitConfig configures the limits applied to every tenant, and overrides of specific tenants
+type RateLimitConfig struct {
+	Default TenantLimits `json:"default"`
+	// Tenants are keyed by tenant id
+	Tenants map[string]TenantLimitsOverride `json:"tenants"`
+}
+
+// For returns the limits of tenant, with default values where tenant override is omitted
+func (c RateLimitConfig) For(tenantID string) TenantLimits {
+	limits := c.Default
+
+	override, ok := c.Tenants[tenantID]
+	if !ok {
+		return limits
+	}
+
+	if override.ReadsPerSecond != nil {
+		limits.ReadsPerSecond = *override.ReadsPerSecond
+	}
+	if override.ReadBurst != nil {
+		limits.ReadBurst = *override.ReadBurst
+	}
+	if override.WritesPerSecond != nil {
+		limits.WritesPerSecond = *override.WritesPerSecond
+	}
+	if override.WriteBurst != nil {
+		limits.WriteBurst = *override.WriteBurst
+	}
+	if override.DailyRegistrations != nil {
+		limits.DailyRegistrations = *override.DailyRegistrations
+	}
+
+	return limits
+}
+
+// IdempotencyConfig configures how long idempotency keys of registrations are kept
+type IdempotencyConfig struct {
+	TTL Duration `json:"ttl"`
+	// Lease is how long a key stays reserved by a request in progress, after it a retry takes the key over.
+	// It must outlast http write timeout, so a running request is not taken over
+	Lease Duration `json:"lease"`
+}
+
+// AuthConfig configures the verification of bearer tokens
+type AuthConfig struct {
+	// JWKSFile is the path of the JSON Web Key Set with the public keys that sign tokens
+	JWKSFile string `json:"jwks_file"`
+	// Issuer and Audience are optional, when set tokens must carry them in iss and aud claims
+	Issuer   string `json:"issuer"`
+	Audience string `json:"audience"`
+}
+
+// EncryptionConfig configures the keys that encrypt personal fields at rest
+type EncryptionConfig struct {
+	// KeysFile is the path of the json file with key encryption keys and blind index key
+	KeysFile string `json:"keys_file"`
+}
+
+type Config struct {
+	HTTP              HTTPConfig              `json:"http"`
+	SMTP              SMTPConfig              `json:"smtp"`
+	EmailVerification EmailVerificationConfig `json:"email_verification"`
+	Receita           ReceitaConfig           `json:"receita"`
+	Tracing           TracingConfig           `json:"tracing"`
+	RateLimit         RateLimitConfig         `json:"rate_limit"`
+	Idempotency       IdempotencyConfig       `json:"idempotency"`
+	Auth              AuthConfig              `json:"auth"`
+	Encryption        EncryptionConfig        `json:"encryption"`
+}
+
+// FileEnv is the environment variable with the path of the optional json config file
+const FileEnv = "PAYEE_CONFIG_FILE"
+
+// minSecretLength is the min size of HMAC secrets, as long as SHA-256 output
+const minSecretLength = 32
+
+var ErrInvalidConfig = errors.New("invalid config")
+
+// Default returns the config values used when neither file nor environment set them
+func Default() Config {
+	return Config{
+		HTTP: HTTPConfig{
+			Addr:            ":8080",
+			MetricsAddr:     ":9090",
+			ReadTimeout:     Duration{5 * time.Second},
+			WriteTimeout:    Duration{10 * time.Second},
+			DrainDelay:      Duration{5 * time.Second},
+			ShutdownTimeout: Duration{30 * time.Second},
+		},
+		SMTP: SMTPConfig{
+			Timeout: Duration{10 * time.Second},
+		},
+		EmailVerification: EmailVerificationConfig{
+			TTL: Duration{24 * time.Hour},
+		},
+		Tracing: TracingConfig{
+			Exporter: NoneTracingExporter,
+		},
+		Idempotency: IdempotencyConfig{
+			TTL:   Duration{24 * time.Hour},
+			Lease: Duration{time.Minute},
+		},
+		RateLimit: RateLimitConfig{
+			Default: TenantLimits{
+				ReadsPerSecond:     50,
+				ReadBurst:          100,
+				WritesPerSecond:    10,
+				WriteBurst:         20,
+				DailyRegistrations: 1000,
+			},
+		},
+	}
+}
+
+// Load returns the default config overridden by the file at PAYEE_CONFIG_FILE, when set,
+// and then by environment variables. lookupEnv is usually os.LookupEnv
+func Load(lookupEnv func(key string) (string, bool)) (Config, error) {
+	cfg := Default()
+
+	if path, ok := lookupEnv(FileEnv); ok && path != "" {
+		if err := cfg.loadFile(path); err != nil {
+			return Config{}, err
+		}
+	}
+
+	if err := cfg.loadEnv(lookupEnv); err != nil {
+		return Config{}, err
+	}
+
+	if err := cfg.Validate(); err != nil {
+		return Config{}, err
+	}
+
+	return cfg, nil
+}
+
+func (c *Config) loadFile(path string) error {
+	file, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("open config file: %w", err)
+	}
+	defer file.Close()
+
+	decoder := json.NewDecoder(file)
+	decoder.DisallowUnknownFields()
+
+	if err := decoder.Decode(c); err != nil {
+		return fmt.Errorf("%w: decode config file: %w", ErrInvalidConfig, err)
+	}
+
+	return nil
+}
+
+func (c *Config) loadEnv(lookupEnv func(key string) (string, bool)) error {
+	vars := []struct {
+		key string
+		set func(v string) error
+	}{
+		{"PAYEE_HTTP_ADDR", setString(&c.HTTP.Addr)},
+		{"PAYEE_HTTP_METRICS_ADDR", setString(&c.HTTP.MetricsAddr)},
+		{"PAYEE_HTTP_READ_TIMEOUT", setDuration(&c.HTTP.ReadTimeout)},
+		{"PAYEE_HTTP_WRITE_TIMEOUT", setDuration(&c.HTTP.WriteTimeout)},
+		{"PAYEE_HTTP_DRAIN_DELAY", setDuration(&c.HTTP.DrainDelay)},
+		{"PAYEE_HTTP_SHUTDOWN_TIMEOUT", setDuration(&c.HTTP.ShutdownTimeout)},
+		{"PAYEE_SMTP_ADDR", setString(&c.SMTP.Addr)},
+		{"PAYEE_SMTP_FROM", setString(&c.SMTP.From)},
+		{"PAYEE_SMTP_USERNAME", setString(&c.SMTP.Username)},
+		{"PAYEE_SMTP_PASSWORD", setString(&c.SMTP.Password)},
+		{"PAYEE_SMTP_TIMEOUT", setDuration(&c.SMTP.Timeout)},
+		{"PAYEE_EMAIL_VERIFICATION_SECRET", setString(&c.EmailVerification.Secret)},
+		{"PAYEE_EMAIL_VERIFICATION_TTL", setDuration(&c.EmailVerification.TTL)},
+		{"PAYEE_EMAIL_CONFIRMATION_URL", setString(&c.EmailVerification.ConfirmationURL)},
+		{"PAYEE_RECEITA_STATUS_FILE", setString(&c.Receita.StatusFile)},
+		{"PAYEE_TRACING_EXPORTER", setString(&c.Tracing.Exporter)},
+		{"PAYEE_RATE_LIMIT_READS_PER_SECOND", setFloat(&c.RateLimit.Default.ReadsPerSecond)},
+		{"PAYEE_RATE_LIMIT_READ_BURST", setInt(&c.RateLimit.Default.ReadBurst)},
+		{"PAYEE_RATE_LIMIT_WRITES_PER_SECOND", setFloat(&c.RateLimit.Default.WritesPerSecond)},
+		{"PAYEE_RATE_LIMIT_WRITE_BURST", setInt(&c.RateLimit.Default.WriteBurst)},
+		{"PAYEE_DAILY_REGISTRATION_QUOTA", setInt(&c.RateLimit.Default.DailyRegistrations)},
+		{"PAYEE_IDEMPOTENCY_TTL", setDuration(&c.Idempotency.TTL)},
+		{"PAYEE_IDEMPOTENCY_LEASE", setDuration(&c.Idempotency.Lease)},
+		{"PAYEE_AUTH_JWKS_FILE", setString(&c.Auth.JWKSFile)},
+		{"PAYEE_AUTH_ISSUER", setString(&c.Auth.Issuer)},
+		{"PAYEE_AUTH_AUDIENCE", setString(&c.Auth.Audience)},
+		{"PAYEE_ENCRYPTION_KEYS_FILE", setString(&c.Encryption.KeysFile)},
+	}
+
+	for _, v := range vars {
+		value, ok := lookupEnv(v.key)
+		if !ok {
+			continue
+		}
+
+		if err := v.set(value); err != nil {
+			return fmt.Errorf("%w: %s: %w", ErrInvalidConfig, v.key, err)
+		}
+	}
+
+	return nil
+}
+
+func setString(target *string) func(string) error {
+	return func(v string) error {
+		*target = v
+		return nil
+	}
+}
+
+func setInt(target *int) func(string) error {
+	return func(v string) error {
+		parsed, err := strconv.Atoi(v)
+		if err != nil {
+			return err
+		}
+
+		*target = parsed
+		return nil
+	}
+}
+
+func setFloat(target *float64) func(string) error {
+	return func(v string) error {
+		parsed, err := strconv.ParseFloat(v, 64)
+		if err != nil {
+			return err
+		}
+
+		*target = parsed
+		return nil
+	}
+}
+
+func setDuration(target *Duration) func(string) error {
+	return func(v string) error {
+		parsed, err := time.ParseDuration(v)
+		if err != nil {
+			return err
+		}
+
+		target.Duration = parsed
+		return nil
+	}
+}
+
+// Validate returns ErrInvalidConfig joined with every invalid value
+func (c Config) Validate() error {
+	var errs []error
+
+	if c.HTTP.Addr == "" {
+		errs = append(errs, errors.New("http addr is required"))
+	}
+
+	switch c.HTTP.MetricsAddr {
+	case "":
+		errs = append(errs, errors.New("http metrics addr is required"))
+	case c.HTTP.Addr:
+		errs = append(errs, errors.New("http metrics addr must be different from http addr"))
+	}
+
+	timeouts := []struct {
+		name  string
+		value Duration
+	}{
+		{"http read timeout", c.HTTP.ReadTimeout},
+		{"http write timeout", c.HTTP.WriteTimeout},
+		{"http shutdown timeout", c.HTTP.ShutdownTimeout},
+		{"smtp timeout", c.SMTP.Timeout},
+		{"email verification ttl", c.EmailVerification.TTL},
+		{"idempotency ttl", c.Idempotency.TTL},
+		{"idempotency lease", c.Idempotency.Lease},
+	}
+	for _, timeout := range timeouts {
+		if timeout.value.Duration <= 0 {
+			errs = append(errs, fmt.Errorf("%s must be positive", timeout.name))
+		}
+	}
+
+	if c.HTTP.DrainDelay.Duration < 0 {
+		errs = append(errs, errors.New("http drain delay must not be negative"))
+	}
+
+	if c.Idempotency.Lease.Duration > 0 && c.Idempotency.Lease.Duration <= c.HTTP.WriteTimeout.Duration {
+		errs = append(errs, errors.New("idempotency lease must be longer than http write timeout"))
+	}
+
+	if c.SMTP.Addr != "" && c.SMTP.From == "" {
+		errs = append(errs, errors.New("smtp from is required when smtp addr is set"))
+	}
+
+	if len(c.EmailVerification.Secret) < minSecretLength {
+		errs = append(errs, fmt.Errorf("email verification secret must have at least %d bytes", minSecretLength))
+	}
+
+	if c.EmailVerification.ConfirmationURL == "" {
+		errs = append(errs, errors.New("email confirmation url is required"))
+	}
+
+	if c.Auth.JWKSFile == "" {
+		errs = append(errs, errors.New("auth jwks file is required"))
+	}
+
+	if c.Encryption.KeysFile == "" {
+		errs = append(errs, errors.New("encryption keys file is required"))
+	}
+
+	if c.Tracing.Exporter != NoneTracingExporter && c.Tracing.Exporter != StdoutTracingExporter {
+		errs = append(errs, fmt.Errorf("tracing exporter must be %s or %s", NoneTracingExporter, StdoutTracingExporter))
+	}
+
+	errs = append(errs, validateLimits("rate limit default", c.RateLimit.Default, true)...)
+
+	// sorted, so errors are reported in the same order on every run
+	tenantIDs := make([]string, 0, len(c.RateLimit.Tenants))
+	for tenantID := range c.RateLimit.Tenants {
+		tenantIDs = append(tenantIDs, tenantID)
+	}
+	sort.Strings(tenantIDs)
+
+	for _, tenantID := range tenantIDs {
+		errs = append(errs, validateLimits("rate limit of tenant "+tenantID, c.RateLimit.For(tenantID), false)...)
+	}
+
+	if len(errs) > 0 {
+		return fmt.Errorf("%w: %w", ErrInvalidConfig, errors.Join(errs...))
+	}
+
+	return nil
+}
+
+// validateLimits rejects negative limits and empty buckets of positive rates. Zero rates turn off
+// the rate limit, which is allowed only in tenant overrides, default rates are required
+func validateLimits(name string, limits TenantLimits, required bool) []error {
+	var errs []error
+
+	buckets := []struct {
+		name  string
+		rate  float64
+		burst int
+	}{
+		{"read", limits.ReadsPerSecond, limits.ReadBurst},
+		{"write", limits.WritesPerSecond, limits.WriteBurst},
+	}
+	for _, b := range buckets {
+		switch {
+		case b.rate < 0:
+			errs = append(errs, fmt.Errorf("%s %ss per second must not be negative", name, b.name))
+		case b.rate == 0 && required:
+			errs = append(errs, fmt.Errorf("%s %ss per second must be positive", name, b.name))
+		}
+
+		switch {
+		case b.burst < 0:
+			errs = append(errs, fmt.Errorf("%s %s burst must not be negative", name, b.name))
+		case b.burst == 0 && b.rate != 0:
+			errs = append(errs, fmt.Errorf("%s %s burst must be positive", name, b.name))
+		}
+	}
+
+	if limits.DailyRegistrations < 0 {
+		errs = append(errs, fmt.Errorf("%s daily registrations must not be negative", name))
+	}
+
+	return errs
+}
@@ -0,0 +1,228 @@
+package config_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/italorfeitosa/payee-account-manager-api/internal/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testSecret = "0123456789abcdef0123456789abcdef"
+
+func lookupEnvFrom(env map[string]string) func(string) (string, bool) {
+	return func(key string) (string, bool) {
+		v, ok := env[key]
+		return v, ok
+	}
+}
+
+func writeConfigFile(t *testing.T, content string) string {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), "config.json")
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+
+	return path
+}
+
+func TestLoad(t *testing.T) {
+	t.Run("given only required env vars should use defaults", func(t *testing.T) {
+		cfg, err := config.Load(lookupEnvFrom(map[string]string{
+			"PAYEE_EMAIL_VERIFICATION_SECRET": testSecret,
+			"PAYEE_EMAIL_CONFIRMATION_URL":    "https://payees.com/confirm-email",
+			"PAYEE_AUTH_JWKS_FILE":            "/etc/payees/jwks.json",
+			"PAYEE_ENCRYPTION_KEYS_FILE":      "/etc/payees/keys.json",
+		}))
+		require.NoError(t, err)
+
+		assert.Equal(t, ":8080", cfg.HTTP.Addr)
+		assert.Equal(t, ":9090", cfg.HTTP.MetricsAddr)
+		assert.Equal(t, 5*time.Second, cfg.HTTP.ReadTimeout.Duration)
+		assert.Equal(t, 10*time.Second, cfg.HTTP.WriteTimeout.Duration)
+		assert.Equal(t, 5*time.Second, cfg.HTTP.DrainDelay.Duration)
+		assert.Equal(t, 30*time.Second, cfg.HTTP.ShutdownTimeout.Duration)
+		assert.Equal(t, 24*time.Hour, cfg.EmailVerification.TTL.Duration)
+		assert.Empty(t, cfg.SMTP.Addr)
+		assert.Equal(t, 10*time.Second, cfg.SMTP.Timeout.Duration)
+		assert.Equal(t, 24*time.Hour, cfg.Idempotency.TTL.Duration)
+		assert.Equal(t, time.Minute, cfg.Idempotency.Lease.Duration)
+	})
+
+	t.Run("given config file and env vars should override file with env vars", func(t *testing.T) {
+		path := writeConfigFile(t, `{
+			"http": {"addr": ":9090", "metrics_addr": ":9091", "read_timeout": "1s"},
+			"smtp": {"addr": "smtp.payees.com:587", "from": "no-reply@payees.com"},
+			"email_verification": {"secret": "`+testSecret+`", "confirmation_url": "https://file.com/confirm"},
+			"auth": {"jwks_file": "/etc/payees/jwks.json", "issuer": "https://auth.payees.com"},
+			"encryption": {"keys_file": "/etc/payees/keys.json"}
+		}`)
+
+		cfg, err := config.Load(lookupEnvFrom(map[string]string{
+			config.FileEnv:                 path,
+			"PAYEE_HTTP_READ_TIMEOUT":      "3s",
+			"PAYEE_EMAIL_CONFIRMATION_URL": "https://env.com/confirm",
+			"PAYEE_AUTH_AUDIENCE":          "payee-account-manager-api",
+		}))
+		require.NoError(t, err)
+
+		assert.Equal(t, ":9090", cfg.HTTP.Addr)
+		assert.Equal(t, ":9091", cfg.HTTP.MetricsAddr)
+		assert.Equal(t, 3*time.Second, cfg.HTTP.ReadTimeout.Duration)
+		assert.Equal(t, 10*time.Second, cfg.HTTP.WriteTimeout.Duration)
+		assert.Equal(t, "smtp.payees.com:587", cfg.SMTP.Addr)
+		assert.Equal(t, "https://env.com/confirm", cfg.EmailVerification.ConfirmationURL)
+		assert.Equal(t, config.AuthConfig{
+			JWKSFile: "/etc/payees/jwks.json",
+			Issuer:   "https://auth.payees.com",
+			Audience: "payee-account-manager-api",
+		}, cfg.Auth)
+	})
+
+	t.Run("given rate limits in env vars and tenant overrides in file should merge them", func(t *testing.T) {
+		path := writeConfigFile(t, `{
+			"email_verification": {"secret": "`+testSecret+`", "confirmation_url": "https://payees.com/confirm-email"},
+			"auth": {"jwks_file": "/etc/payees/jwks.json"},
+			"encryption": {"keys_file": "/etc/payees/keys.json"},
+			"rate_limit": {"tenants": {"importer": {"write_burst": 200, "daily_registrations": 50000}}}
+		}`)
+
+		cfg, err := config.Load(lookupEnvFrom(map[string]string{
+			config.FileEnv:                       path,
+			"PAYEE_RATE_LIMIT_WRITES_PER_SECOND": "2.5",
+			"PAYEE_DAILY_REGISTRATION_QUOTA":     "10",
+		}))
+		require.NoError(t, err)
+
+		assert.Equal(t, config.TenantLimits{
+			ReadsPerSecond:     50,
+			ReadBurst:          100,
+			WritesPerSecond:    2.5,
+			WriteBurst:         20,
+			DailyRegistrations: 10,
+		}, cfg.RateLimit.For("any"))
+		assert.Equal(t, config.TenantLimits{
+			ReadsPerSecond:     50,
+			ReadBurst:          100,
+			WritesPerSecond:    2.5,
+			WriteBurst:         200,
+			DailyRegistrations: 50000,
+		}, cfg.RateLimit.For("importer"))
+	})
+
+	t.Run("given explicit zero tenant overrides should turn off limits instead of inheriting them", func(t *testing.T) {
+		path := writeConfigFile(t, `{
+			"email_verification": {"secret": "`+testSecret+`", "confirmation_url": "https://payees.com/confirm-email"},
+			"auth": {"jwks_file": "/etc/payees/jwks.json"},
+			"encryption": {"keys_file": "/etc/payees/keys.json"},
+			"rate_limit": {"tenants": {"importer": {"reads_per_second": 0, "writes_per_second": 0, "daily_registrations": 0}}}
+		}`)
+
+		cfg, err := config.Load(lookupEnvFrom(map[string]string{config.FileEnv: path}))
+		require.NoError(t, err)
+
+		assert.Equal(t, config.TenantLimits{
+			ReadsPerSecond:     0,
+			ReadBurst:          100,
+			WritesPerSecond:    0,
+			WriteBurst:         20,
+			DailyRegistrations: 0,
+		}, cfg.RateLimit.For("importer"))
+		assert.Equal(t, 1000, cfg.RateLimit.For("any").DailyRegistrations)
+	})
+
+	t.Run("given unknown field in config file should return error", func(t *testing.T) {
+		path := writeConfigFile(t, `{"http": {"port": 8080}}`)
+
+		_, err := config.Load(lookupEnvFrom(map[string]string{config.FileEnv: path}))
+		assert.ErrorIs(t, err, config.ErrInvalidConfig)
+	})
+
+	t.Run("given invalid duration env var should return error", func(t *testing.T) {
+		_, err := config.Load(lookupEnvFrom(map[string]string{
+			"PAYEE_EMAIL_VERIFICATION_SECRET": testSecret,
+			"PAYEE_EMAIL_CONFIRMATION_URL":    "https://payees.com/confirm-email",
+			"PAYEE_AUTH_JWKS_FILE":            "/etc/payees/jwks.json",
+			"PAYEE_ENCRYPTION_KEYS_FILE":      "/etc/payees/keys.json",
+			"PAYEE_HTTP_WRITE_TIMEOUT":        "ten seconds",
+		}))
+		assert.ErrorIs(t, err, config.ErrInvalidConfig)
+		assert.ErrorContains(t, err, "PAYEE_HTTP_WRITE_TIMEOUT")
+	})
+
+	t.Run("given missing config file should return error", func(t *testing.T) {
+		_, err := config.Load(lookupEnvFrom(map[string]string{config.FileEnv: "/not/found.json"}))
+		assert.Error(t, err)
+	})
+}
+
+func TestConfig_Validate(t *testing.T) {
+	valid := func() config.Config {
+		cfg := config.Default()
+		cfg.EmailVerification.Secret = testSecret
+		cfg.EmailVerification.ConfirmationURL = "https://payees.com/confirm-email"
+		cfg.Auth.JWKSFile = "/etc/payees/jwks.json"
+		cfg.Encryption.KeysFile = "/etc/payees/keys.json"
+		return cfg
+	}
+
+	tests := []struct {
+		name    string
+		mutate  func(cfg *config.Config)
+		wantErr string
+	}{
+		{"given valid config should return no error", func(*config.Config) {}, ""},
+		{"given empty addr should return error", func(cfg *config.Config) { cfg.HTTP.Addr = "" }, "http addr is required"},
+		{"given empty metrics addr should return error", func(cfg *config.Config) { cfg.HTTP.MetricsAddr = "" }, "http metrics addr is required"},
+		{"given metrics addr equal to addr should return error", func(cfg *config.Config) { cfg.HTTP.MetricsAddr = cfg.HTTP.Addr }, "http metrics addr must be different from http addr"},
+		{"given zero read timeout should return error", func(cfg *config.Config) { cfg.HTTP.ReadTimeout.Duration = 0 }, "http read timeout must be positive"},
+		{"given zero smtp timeout should return error", func(cfg *config.Config) { cfg.SMTP.Timeout.Duration = 0 }, "smtp timeout must be positive"},
+		{"given negative drain delay should return error", func(cfg *config.Config) { cfg.HTTP.DrainDelay.Duration = -time.Second }, "http drain delay must not be negative"},
+		{"given negative ttl should return error", func(cfg *config.Config) { cfg.EmailVerification.TTL.Duration = -time.Hour }, "email verification ttl must be positive"},
+		{"given zero idempotency lease should return error", func(cfg *config.Config) { cfg.Idempotency.Lease.Duration = 0 }, "idempotency lease must be positive"},
+		{"given idempotency lease within write timeout should return error", func(cfg *config.Config) {
+			cfg.Idempotency.Lease.Duration = cfg.HTTP.WriteTimeout.Duration
+		}, "idempotency lease must be longer than http write timeout"},
+		{"given smtp addr without from should return error", func(cfg *config.Config) { cfg.SMTP.Addr = "localhost:25" }, "smtp from is required"},
+		{"given short secret should return error", func(cfg *config.Config) { cfg.EmailVerification.Secret = "short" }, "secret must have at least 32 bytes"},
+		{"given empty confirmation url should return error", func(cfg *config.Config) { cfg.EmailVerification.ConfirmationURL = "" }, "confirmation url is required"},
+		{"given empty jwks file should return error", func(cfg *config.Config) { cfg.Auth.JWKSFile = "" }, "auth jwks file is required"},
+		{"given empty encryption keys file should return error", func(cfg *config.Config) { cfg.Encryption.KeysFile = "" }, "encryption keys file is required"},
+		{"given stdout tracing exporter should return no error", func(cfg *config.Config) { cfg.Tracing.Exporter = config.StdoutTracingExporter }, ""},
+		{"given zero default read rate should return error", func(cfg *config.Config) { cfg.RateLimit.Default.ReadsPerSecond = 0 }, "rate limit default reads per second must be positive"},
+		{"given negative tenant burst should return error", func(cfg *config.Config) {
+			cfg.RateLimit.Tenants = map[string]config.TenantLimitsOverride{"importer": {WriteBurst: ptr(-1)}}
+		}, "rate limit of tenant importer write burst must not be negative"},
+		{"given zero tenant burst of a limited rate should return error", func(cfg *config.Config) {
+			cfg.RateLimit.Tenants = map[string]config.TenantLimitsOverride{"importer": {ReadBurst: ptr(0)}}
+		}, "rate limit of tenant importer read burst must be positive"},
+		{"given zero tenant rates should return no error", func(cfg *config.Config) {
+			cfg.RateLimit.Tenants = map[string]config.TenantLimitsOverride{"importer": {ReadsPerSecond: ptr(0.0), WritesPerSecond: ptr(0.0)}}
+		}, ""},
+		{"given negative daily registrations should return error", func(cfg *config.Config) { cfg.RateLimit.Default.DailyRegistrations = -1 }, "daily registrations must not be negative"},
+		{"given unknown tracing exporter should return error", func(cfg *config.Config) { cfg.Tracing.Exporter = "jaeger" }, "tracing exporter must be none or stdout"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := valid()
+			tt.mutate(&cfg)
+
+			err := cfg.Validate()
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+
+			assert.ErrorIs(t, err, config.ErrInvalidConfig)
+			assert.ErrorContains(t, err, tt.wantErr)
+		})
+	}
+}
+
+func ptr[T any](v T) *T {
+	return &v
+}
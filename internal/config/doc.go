@@ -0,0 +1,3 @@
+// config package loads the typed application configuration from an optional json file
+// and environment variables, which take precedence over file values
+package config
@@ -0,0 +1,120 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+	"unicode/utf8"
+)
+
+// Address is value object that represents a Brazilian postal address
+type Address struct {
+	street       string
+	number       string
+	complement   string
+	neighborhood string
+	city         string
+	uf           UF
+	cep          CEP
+}
+
+func (a Address) Street() string {
+	return a.street
+}
+
+// Number returns the building number, "S/N" when address has no number
+func (a Address) Number() string {
+	return a.number
+}
+
+func (a Address) Complement() string {
+	return a.complement
+}
+
+func (a Address) Neighborhood() string {
+	return a.neighborhood
+}
+
+func (a Address) City() string {
+	return a.city
+}
+
+func (a Address) UF() UF {
+	return a.uf
+}
+
+func (a Address) CEP() CEP {
+	return a.cep
+}
+
+// AddressFieldMaxLength is the max amount of characters of each address field
+const AddressFieldMaxLength = 128
+
+var (
+	ErrIncompleteAddress = errors.New("address must have street, number, neighborhood, city, uf and cep")
+	ErrAddressTooLong    = errors.New("address fields must have at most 128 characters")
+	ErrCEPUFMismatch     = errors.New("cep does not belong to address uf")
+)
+
+// NewAddress creates a new instance of Address, complement is optional and cep must belong to uf
+func NewAddress(street, number, complement, neighborhood, city, uf, cep string) (Address, error) {
+	address := Address{
+		street:       strings.Join(strings.Fields(street), " "),
+		number:       strings.ToUpper(strings.TrimSpace(number)),
+		complement:   strings.Join(strings.Fields(complement), " "),
+		neighborhood: strings.Join(strings.Fields(neighborhood), " "),
+		city:         strings.Join(strings.Fields(city), " "),
+	}
+
+	required := []struct{ name, value string }{
+		{"street", address.street},
+		{"number", address.number},
+		{"neighborhood", address.neighborhood},
+		{"city", address.city},
+	}
+	for _, field := range required {
+		if field.value == "" {
+			return Address{}, fmt.Errorf("%w: %s is empty", ErrIncompleteAddress, field.name)
+		}
+	}
+
+	for _, value := range []string{address.street, address.number, address.complement, address.neighborhood, address.city} {
+		if utf8.RuneCountInString(value) > AddressFieldMaxLength {
+			return Address{}, ErrAddressTooLong
+		}
+	}
+
+	var err error
+
+	address.uf, err = NewUF(uf)
+	if err != nil {
+		return Address{}, err
+	}
+
+	address.cep, err = NewCEP(cep)
+	if err != nil {
+		return Address{}, err
+	}
+
+	if address.cep.UF() != address.uf {
+		return Address{}, ErrCEPUFMismatch
+	}
+
+	return address, nil
+}
+
+// restoreAddress keeps address restored from database as is, logging it when tempered
+func restoreAddress(ctx context.Context, address Address, attrs ...slog.Attr) Address {
+	_, err := NewAddress(
+		address.street, address.number, address.complement, address.neighborhood,
+		address.city, address.uf.String(), address.cep.Value(),
+	)
+	if err != nil {
+		attrs = append(attrs, slog.String("error", err.Error()))
+		slog.LogAttrs(ctx, slog.LevelWarn, "tempered address with invalid values", attrs...)
+	}
+
+	return address
+}
@@ -0,0 +1,63 @@
+package domain_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/italorfeitosa/payee-account-manager-api/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewAddress(t *testing.T) {
+	t.Run("given a valid address should return Address with trimmed values", func(t *testing.T) {
+		got, err := domain.NewAddress(" Avenida  Paulista ", "1578", "", "Bela Vista", "São Paulo", "sp", "01310200")
+
+		require.NoError(t, err)
+		assert.Equal(t, "Avenida Paulista", got.Street())
+		assert.Equal(t, "1578", got.Number())
+		assert.Equal(t, "Bela Vista", got.Neighborhood())
+		assert.Equal(t, "São Paulo", got.City())
+		assert.Equal(t, domain.UFSP, got.UF())
+		assert.Equal(t, "01310-200", got.CEP().String())
+	})
+
+	tests := []struct {
+		name    string
+		args    [7]string
+		wantErr error
+	}{
+		{
+			name:    "given an address without street should return error",
+			args:    [7]string{"", "1578", "", "Bela Vista", "São Paulo", "SP", "01310-200"},
+			wantErr: domain.ErrIncompleteAddress,
+		},
+		{
+			name:    "given an address with invalid uf should return error",
+			args:    [7]string{"Avenida Paulista", "1578", "", "Bela Vista", "São Paulo", "XX", "01310-200"},
+			wantErr: domain.ErrInvalidUF,
+		},
+		{
+			name:    "given an address with invalid cep should return error",
+			args:    [7]string{"Avenida Paulista", "1578", "", "Bela Vista", "São Paulo", "SP", "0131"},
+			wantErr: domain.ErrInvalidCEP,
+		},
+		{
+			name:    "given a cep of another uf should return error",
+			args:    [7]string{"Avenida Paulista", "1578", "", "Bela Vista", "São Paulo", "RJ", "01310-200"},
+			wantErr: domain.ErrCEPUFMismatch,
+		},
+		{
+			name:    "given a field longer than 128 characters should return error",
+			args:    [7]string{"Avenida Paulista", "1578", strings.Repeat("a", 129), "Bela Vista", "São Paulo", "SP", "01310-200"},
+			wantErr: domain.ErrAddressTooLong,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := domain.NewAddress(tt.args[0], tt.args[1], tt.args[2], tt.args[3], tt.args[4], tt.args[5], tt.args[6])
+
+			assert.ErrorIs(t, err, tt.wantErr)
+		})
+	}
+}
@@ -8,3 +8,8 @@ type BankAccount struct {
 	BankCode      string
 	BankIspb      string
 }
+
+// MaskedAccountNumber returns account number keeping only the last 2 digits visible (Ex: ******65)
+func (b BankAccount) MaskedAccountNumber() string {
+	return maskAllButLast(b.AccountNumber, 2)
+}
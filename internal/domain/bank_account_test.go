@@ -0,0 +1,14 @@
+package domain_test
+
+import (
+	"testing"
+
+	"github.com/italorfeitosa/payee-account-manager-api/internal/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBankAccount_MaskedAccountNumber(t *testing.T) {
+	assert.Equal(t, "******65", domain.BankAccount{AccountNumber: "65465465"}.MaskedAccountNumber())
+	assert.Equal(t, "***-45", domain.BankAccount{AccountNumber: "123-45"}.MaskedAccountNumber())
+	assert.Equal(t, "", domain.BankAccount{}.MaskedAccountNumber())
+}
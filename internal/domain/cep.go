@@ -0,0 +1,111 @@
+package domain
+
+import (
+	"errors"
+	"regexp"
+	"strings"
+)
+
+// CEP is value object that represents a Brazilian postal code (Código de Endereçamento Postal)
+type CEP struct {
+	value string
+}
+
+// Value returns the 8 digits of CEP
+func (c CEP) Value() string {
+	return c.value
+}
+
+// String returns CEP formatted (Ex: 01310-100)
+func (c CEP) String() string {
+	if len(c.value) != 8 {
+		return c.value
+	}
+
+	return c.value[:5] + "-" + c.value[5:]
+}
+
+// UF returns the federative unit CEP belongs to
+func (c CEP) UF() UF {
+	uf, _ := CEPState(c.value)
+	return uf
+}
+
+var (
+	EmptyCEP CEP
+
+	ErrInvalidCEP = errors.New("invalid cep")
+
+	CEPRegex = regexp.MustCompile(`^[0-9]{5}-?[0-9]{3}$`)
+)
+
+// NewCEP creates a new instance of CEP, formatted or not, it must be in a range of a federative unit
+func NewCEP(v string) (CEP, error) {
+	v = strings.TrimSpace(v)
+	if !CEPRegex.MatchString(v) {
+		return EmptyCEP, ErrInvalidCEP
+	}
+
+	value := keepOnlyNumbers(v)
+	if _, ok := CEPState(value); !ok {
+		return EmptyCEP, ErrInvalidCEP
+	}
+
+	return CEP{value}, nil
+}
+
+// cepRange is an inclusive range of CEP prefixes (first 5 digits) of a federative unit
+type cepRange struct {
+	from, to string
+	uf       UF
+}
+
+// cepRanges are the CEP ranges of federative units, as assigned by Correios
+var cepRanges = []cepRange{
+	{"01000", "19999", UFSP},
+	{"20000", "28999", UFRJ},
+	{"29000", "29999", UFES},
+	{"30000", "39999", UFMG},
+	{"40000", "48999", UFBA},
+	{"49000", "49999", UFSE},
+	{"50000", "56999", UFPE},
+	{"57000", "57999", UFAL},
+	{"58000", "58999", UFPB},
+	{"59000", "59999", UFRN},
+	{"60000", "63999", UFCE},
+	{"64000", "64999", UFPI},
+	{"65000", "65999", UFMA},
+	{"66000", "68899", UFPA},
+	{"68900", "68999", UFAP},
+	{"69000", "69299", UFAM},
+	{"69300", "69399", UFRR},
+	{"69400", "69899", UFAM},
+	{"69900", "69999", UFAC},
+	{"70000", "72799", UFDF},
+	{"72800", "72999", UFGO},
+	{"73000", "73699", UFDF},
+	{"73700", "76799", UFGO},
+	{"76800", "76999", UFRO},
+	{"77000", "77999", UFTO},
+	{"78000", "78899", UFMT},
+	{"79000", "79999", UFMS},
+	{"80000", "87999", UFPR},
+	{"88000", "89999", UFSC},
+	{"90000", "99999", UFRS},
+}
+
+// CEPState returns the federative unit of CEP digits, false if CEP is not in any range
+func CEPState(cep string) (UF, bool) {
+	if len(cep) != 8 {
+		return "", false
+	}
+
+	prefix := cep[:5]
+	for _, r := range cepRanges {
+		if prefix >= r.from && prefix <= r.to {
+			return r.uf, true
+		}
+	}
+
+	return "", false
+}
@@ -0,0 +1,73 @@
+package domain_test
+
+import (
+	"testing"
+
+	"github.com/italorfeitosa/payee-account-manager-api/internal/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewCEP(t *testing.T) {
+	tests := []struct {
+		name       string
+		arg        string
+		wantString string
+		wantUF     domain.UF
+		wantErr    error
+	}{
+		{
+			name:       "given a formatted cep should return CEP",
+			arg:        "01310-100",
+			wantString: "01310-100",
+			wantUF:     domain.UFSP,
+		},
+		{
+			name:       "given an unformatted cep should return formatted CEP",
+			arg:        "20040020",
+			wantString: "20040-020",
+			wantUF:     domain.UFRJ,
+		},
+		{
+			name:       "given a cep of Distrito Federal second range should return DF",
+			arg:        "73010-000",
+			wantString: "73010-000",
+			wantUF:     domain.UFDF,
+		},
+		{
+			name:       "given a cep between Distrito Federal ranges should return GO",
+			arg:        "72800-000",
+			wantString: "72800-000",
+			wantUF:     domain.UFGO,
+		},
+		{
+			name:       "given a cep of Roraima inside Amazonas ranges should return RR",
+			arg:        "69301-000",
+			wantString: "69301-000",
+			wantUF:     domain.UFRR,
+		},
+		{
+			name:    "given a cep out of any range should return error",
+			arg:     "00999-000",
+			wantErr: domain.ErrInvalidCEP,
+		},
+		{
+			name:    "given a cep with wrong length should return error",
+			arg:     "1310-100",
+			wantErr: domain.ErrInvalidCEP,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := domain.NewCEP(tt.arg)
+			if tt.wantErr != nil {
+				assert.Equal(t, domain.EmptyCEP, got)
+				assert.Equal(t, tt.wantErr, err)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantString, got.String())
+			assert.Equal(t, tt.wantUF, got.UF())
+		})
+	}
+}
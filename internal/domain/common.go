@@ -21,3 +21,54 @@ func keepOnlyNumbers(input string) string {
 
 	return builder.String()
 }
+
+// keepOnlyAlphanumerics returns a string containing only the ASCII digits and letters from the input string.
+func keepOnlyAlphanumerics(input string) string {
+	var builder strings.Builder
+	builder.Grow(len(input))
+
+	for _, r := range input {
+		if (r >= '0' && r <= '9') || (r >= 'A' && r <= 'Z') || (r >= 'a' && r <= 'z') {
+			builder.WriteRune(r)
+		}
+	}
+
+	return builder.String()
+}
+
+// maskRunes replaces every digit or letter of input by '*', except the ones positioned
+// inside [visibleFrom, visibleTo), keeping separators untouched
+func maskRunes(input string, visibleFrom, visibleTo int) string {
+	var builder strings.Builder
+	builder.Grow(len(input))
+
+	position := 0
+	for _, r := range input {
+		if !unicode.IsDigit(r) && !unicode.IsLetter(r) {
+			builder.WriteRune(r)
+			continue
+		}
+
+		if position >= visibleFrom && position < visibleTo {
+			builder.WriteRune(r)
+		} else {
+			builder.WriteRune('*')
+		}
+
+		position++
+	}
+
+	return builder.String()
+}
+
+// maskAllButLast replaces every digit or letter of input by '*', except the last visible ones
+func maskAllButLast(input string, visible int) string {
+	total := 0
+	for _, r := range input {
+		if unicode.IsDigit(r) || unicode.IsLetter(r) {
+			total++
+		}
+	}
+
+	return maskRunes(input, total-visible, total)
+}
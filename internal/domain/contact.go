@@ -0,0 +1,150 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+type ContactType string
+
+const (
+	EmailContactType ContactType = "EMAIL"
+	PhoneContactType ContactType = "PHONE"
+)
+
+// Contact is value object that represents a typed contact of payee, email or phone
+type Contact struct {
+	typ     ContactType
+	value   string
+	primary bool
+}
+
+func (c Contact) Type() ContactType {
+	return c.typ
+}
+
+// Value returns the email, or the phone with country code and only numbers (Ex: 551131234567)
+func (c Contact) Value() string {
+	return c.value
+}
+
+// Masked returns the email masked as Email, or the phone keeping country code, area code,
+// first and last four digits visible (Ex: +55 (11) 9****-5678)
+func (c Contact) Masked() string {
+	switch c.typ {
+	case EmailContactType:
+		return Email{c.value}.Masked()
+	case PhoneContactType:
+		if len(c.value) < 12 {
+			return maskAllButLast(c.value, 4)
+		}
+
+		number := c.value[4:]
+		return "+" + c.value[:2] + " (" + c.value[2:4] + ") " + number[:1] + strings.Repeat("*", len(number)-5) + "-" + number[len(number)-4:]
+	default:
+		return maskAllButLast(c.value, 4)
+	}
+}
+
+// Primary reports if contact is the preferred one of payee
+func (c Contact) Primary() bool {
+	return c.primary
+}
+
+var (
+	ErrInvalidContactType     = errors.New("invalid contact type, must be EMAIL or PHONE")
+	ErrInvalidPhone           = errors.New("invalid phone")
+	ErrDuplicatedContact      = errors.New("duplicated contact")
+	ErrPrimaryContactRequired = errors.New("contacts must have exactly one primary contact")
+
+	// PhoneRegex matches landlines (8 digits starting by 2 to 5) and mobiles (9 digits starting by 9),
+	// with area code and optional country code
+	PhoneRegex = regexp.MustCompile(`^(?:\+?55)?([1-9][0-9])([2-5][0-9]{7}|9[0-9]{8})$`)
+)
+
+// NewContact creates a new instance of Contact, phone accepts spaces, parentheses and dashes
+// and its area code must be in Anatel DDD list
+func NewContact(typ, value string, primary bool) (Contact, error) {
+	switch ContactType(strings.ToUpper(strings.TrimSpace(typ))) {
+	case EmailContactType:
+		email, err := NewEmail(value)
+		if err != nil {
+			return Contact{}, err
+		}
+
+		return Contact{EmailContactType, email.Value(), primary}, nil
+	case PhoneContactType:
+		phone, err := newPhone(value)
+		if err != nil {
+			return Contact{}, err
+		}
+
+		return Contact{PhoneContactType, phone, primary}, nil
+	default:
+		return Contact{}, ErrInvalidContactType
+	}
+}
+
+// newPhone returns phone only numbers, prefixed by Brazil country code
+func newPhone(value string) (string, error) {
+	value = telefoneSeparators.Replace(strings.TrimSpace(value))
+
+	matches := PhoneRegex.FindStringSubmatch(value)
+	if matches == nil {
+		return "", ErrInvalidPhone
+	}
+
+	if _, ok := DDDState(matches[1]); !ok {
+		return "", fmt.Errorf("%w: %w", ErrInvalidPhone, ErrInvalidDDD)
+	}
+
+	return "55" + matches[1] + matches[2], nil
+}
+
+// validateContacts checks contacts are unique and exactly one is primary, empty contacts are valid
+func validateContacts(contacts []Contact) error {
+	if len(contacts) == 0 {
+		return nil
+	}
+
+	primaries := 0
+	for i, contact := range contacts {
+		if contact.primary {
+			primaries++
+		}
+
+		for _, other := range contacts[:i] {
+			if other.typ == contact.typ && other.value == contact.value {
+				return ErrDuplicatedContact
+			}
+		}
+	}
+
+	if primaries != 1 {
+		return ErrPrimaryContactRequired
+	}
+
+	return nil
+}
+
+// restoreContacts keeps contacts restored from database as they are, logging tempered ones
+func restoreContacts(ctx context.Context, contacts []Contact, attrs ...slog.Attr) []Contact {
+	for _, contact := range contacts {
+		if _, err := NewContact(string(contact.typ), contact.value, contact.primary); err != nil {
+			contactAttrs := append(slices.Clip(attrs), slog.String("contact_type", string(contact.typ)), slog.String("error", err.Error()))
+			slog.LogAttrs(ctx, slog.LevelWarn, "tempered contact with invalid values", contactAttrs...)
+		}
+	}
+
+	if err := validateContacts(contacts); err != nil {
+		attrs = append(attrs, slog.String("error", err.Error()))
+		slog.LogAttrs(ctx, slog.LevelWarn, "tempered contacts with invalid values", attrs...)
+	}
+
+	return contacts
+}
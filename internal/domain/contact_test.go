@@ -0,0 +1,116 @@
+package domain_test
+
+import (
+	"testing"
+
+	"github.com/italorfeitosa/payee-account-manager-api/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewContact(t *testing.T) {
+	tests := []struct {
+		name      string
+		typ       string
+		value     string
+		wantType  domain.ContactType
+		wantValue string
+		wantErr   error
+	}{
+		{
+			name:      "given an email contact should return Contact",
+			typ:       "EMAIL",
+			value:     "italo@feitosa.com",
+			wantType:  domain.EmailContactType,
+			wantValue: "italo@feitosa.com",
+		},
+		{
+			name:      "given a formatted landline phone should return only numbers with country code",
+			typ:       "phone",
+			value:     "(11) 3123-4567",
+			wantType:  domain.PhoneContactType,
+			wantValue: "551131234567",
+		},
+		{
+			name:      "given a mobile phone with country code should return Contact",
+			typ:       "PHONE",
+			value:     "+55 21 91234-5678",
+			wantType:  domain.PhoneContactType,
+			wantValue: "5521912345678",
+		},
+		{
+			name:    "given a phone with unknown area code should return error",
+			typ:     "PHONE",
+			value:   "(20) 3123-4567",
+			wantErr: domain.ErrInvalidDDD,
+		},
+		{
+			name:    "given a landline starting by 7 should return error",
+			typ:     "PHONE",
+			value:   "(11) 7123-4567",
+			wantErr: domain.ErrInvalidPhone,
+		},
+		{
+			name:    "given an invalid email should return error",
+			typ:     "EMAIL",
+			value:   "italo",
+			wantErr: domain.ErrInvalidEmail,
+		},
+		{
+			name:    "given an unknown type should return error",
+			typ:     "FAX",
+			value:   "1131234567",
+			wantErr: domain.ErrInvalidContactType,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := domain.NewContact(tt.typ, tt.value, true)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantType, got.Type())
+			assert.Equal(t, tt.wantValue, got.Value())
+			assert.True(t, got.Primary())
+		})
+	}
+}
+
+func TestContact_Masked(t *testing.T) {
+	tests := []struct {
+		name  string
+		typ   string
+		value string
+		want  string
+	}{
+		{
+			name:  "given an email contact should mask as email",
+			typ:   "EMAIL",
+			value: "italo@feitosa.com",
+			want:  "i***@feitosa.com",
+		},
+		{
+			name:  "given a mobile phone should keep area code, first and last four digits",
+			typ:   "PHONE",
+			value: "(11) 91234-5678",
+			want:  "+55 (11) 9****-5678",
+		},
+		{
+			name:  "given a landline phone should keep area code, first and last four digits",
+			typ:   "PHONE",
+			value: "(11) 3123-4567",
+			want:  "+55 (11) 3***-4567",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			contact, err := domain.NewContact(tt.typ, tt.value, true)
+			require.NoError(t, err)
+
+			assert.Equal(t, tt.want, contact.Masked())
+		})
+	}
+}
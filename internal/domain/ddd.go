@@ -0,0 +1,38 @@
+package domain
+
+// dddStates maps each area code (DDD) of Anatel numbering plan to its federative unit
+var dddStates = map[string]UF{
+	"11": UFSP, "12": UFSP, "13": UFSP, "14": UFSP, "15": UFSP, "16": UFSP, "17": UFSP, "18": UFSP, "19": UFSP,
+	"21": UFRJ, "22": UFRJ, "24": UFRJ,
+	"27": UFES, "28": UFES,
+	"31": UFMG, "32": UFMG, "33": UFMG, "34": UFMG, "35": UFMG, "37": UFMG, "38": UFMG,
+	"41": UFPR, "42": UFPR, "43": UFPR, "44": UFPR, "45": UFPR, "46": UFPR,
+	"47": UFSC, "48": UFSC, "49": UFSC,
+	"51": UFRS, "53": UFRS, "54": UFRS, "55": UFRS,
+	"61": UFDF,
+	"62": UFGO, "64": UFGO,
+	"63": UFTO,
+	"65": UFMT, "66": UFMT,
+	"67": UFMS,
+	"68": UFAC,
+	"69": UFRO,
+	"71": UFBA, "73": UFBA, "74": UFBA, "75": UFBA, "77": UFBA,
+	"79": UFSE,
+	"81": UFPE, "87": UFPE,
+	"82": UFAL,
+	"83": UFPB,
+	"84": UFRN,
+	"85": UFCE, "88": UFCE,
+	"86": UFPI, "89": UFPI,
+	"91": UFPA, "93": UFPA, "94": UFPA,
+	"92": UFAM, "97": UFAM,
+	"95": UFRR,
+	"96": UFAP,
+	"98": UFMA, "99": UFMA,
+}
+
+// DDDState returns the federative unit of area code (DDD), false if area code does not exist
+func DDDState(ddd string) (UF, bool) {
+	uf, ok := dddStates[ddd]
+	return uf, ok
+}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"regexp"
 	"strconv"
+	"strings"
 )
 
 // Document is an interface that represents value objects for government or tax identification documents (Ex: CPF and CNPJ)
@@ -15,6 +16,14 @@ type Document interface {
 	// If CNPJ, return as 00.000.000/0001-00
 	// If CPF, return as 000.000.000-00
 	String() string
+	// Masked returns the formatted value of document hiding the digits that identify the holder
+	// If CNPJ, return as **.000.000/0001-**
+	// If CPF, return as ***.000.000-**
+	Masked() string
+	// DocumentType returns CPF, CNPJ, UNKNOWN when document was tempered or ANONYMIZED
+	DocumentType() DocumentType
+	// PersonKind returns NATURAL for CPF, LEGAL for CNPJ or UNKNOWN when document was tempered
+	PersonKind() PersonKind
 }
 
 var ErrInvalidDocument = errors.New("invalid document")
@@ -51,6 +60,19 @@ func (c CPF) String() string {
 	return cpfFormatPattern.ReplaceAllString(c.value, "$1.$2.$3-$4")
 }
 
+// Masked returns the formatted value of document keeping only the middle digits visible (Ex: ***.000.000-**)
+func (c CPF) Masked() string {
+	return maskRunes(c.String(), 3, 9)
+}
+
+func (CPF) DocumentType() DocumentType {
+	return CPFDocumentType
+}
+
+func (CPF) PersonKind() PersonKind {
+	return NaturalPersonKind
+}
+
 var (
 	EmptyCPF CPF
 
@@ -144,22 +166,72 @@ func (c CNPJ) Value() string {
 	return c.value
 }
 
-var cnpjFormatPattern = regexp.MustCompile(`([\d]{2})([\d]{3})([\d]{3})([\d]{4})([\d]{2})`)
+var cnpjFormatPattern = regexp.MustCompile(`([\dA-Z]{2})([\dA-Z]{3})([\dA-Z]{3})([\dA-Z]{4})([\d]{2})`)
 
-// String returns the underlying value of document formatted (Ex: 00.000.000/0001-00)
+// String returns the underlying value of document formatted (Ex: 00.000.000/0001-00 or 12.ABC.345/01DE-35)
 func (c CNPJ) String() string {
 	return cnpjFormatPattern.ReplaceAllString(c.value, "$1.$2.$3/$4-$5")
 }
 
+// Masked returns the formatted value of document hiding the first and check digits (Ex: **.000.000/0001-**)
+func (c CNPJ) Masked() string {
+	return maskRunes(c.String(), 2, 12)
+}
+
+// Root returns the first 8 characters of CNPJ, which identify the company regardless of branch
+func (c CNPJ) Root() string {
+	return c.value[:8]
+}
+
+// BranchOrder returns the 4 characters after root, which identify the branch of company (Ex: 0001)
+func (c CNPJ) BranchOrder() string {
+	return c.value[8:12]
+}
+
+// IsHeadquarters reports if CNPJ belongs to company headquarters (matriz), which branch order is 0001
+func (c CNPJ) IsHeadquarters() bool {
+	return c.BranchOrder() == HeadquartersBranchOrder
+}
+
+func (CNPJ) DocumentType() DocumentType {
+	return CNPJDocumentType
+}
+
+func (CNPJ) PersonKind() PersonKind {
+	return LegalPersonKind
+}
+
 var (
 	EmptyCNPJ CNPJ
 
 	ErrInvalidCNPJ = errors.New("invalid cnpj number")
 
-	CNPJRegex = regexp.MustCompile(`^[0-9]{2}[\.]?[0-9]{3}[\.]?[0-9]{3}[\/]?[0-9]{4}[-]?[0-9]{2}$`)
+	// CNPJRegex accepts numeric and alphanumeric CNPJ, where the first 12 positions
+	// can have uppercase letters, as issued by Receita Federal since July 2026
+	CNPJRegex = regexp.MustCompile(`^[0-9A-Z]{2}[\.]?[0-9A-Z]{3}[\.]?[0-9A-Z]{3}[\/]?[0-9A-Z]{4}[-]?[0-9]{2}$`)
 )
 
-// NewCNPJ returns an new instance of CNPJ value object
+const HeadquartersBranchOrder = "0001"
+
+var (
+	ErrInvalidCNPJRoot = errors.New("invalid cnpj root")
+
+	CNPJRootRegex = regexp.MustCompile(`^[0-9A-Z]{2}[\.]?[0-9A-Z]{3}[\.]?[0-9A-Z]{3}$`)
+)
+
+// NewCNPJRoot validates and cleans the 8 characters root of CNPJ (Ex: 19.039.318 returns 19039318)
+func NewCNPJRoot(v string) (string, error) {
+	v = strings.ToUpper(strings.TrimSpace(v))
+
+	if !CNPJRootRegex.MatchString(v) {
+		return "", ErrInvalidCNPJRoot
+	}
+
+	return keepOnlyAlphanumerics(v), nil
+}
+
+// NewCNPJ returns an new instance of CNPJ value object, numeric or alphanumeric.
+// Letters are accepted in lower case, and normalized to upper case
 func NewCNPJ(v string) (CNPJ, error) {
 	cnpj, err := cleanAndValidateCNPJ(v)
 	if err != nil {
@@ -170,23 +242,22 @@ func NewCNPJ(v string) (CNPJ, error) {
 }
 
 func cleanAndValidateCNPJ(documentNumber string) (string, error) {
+	documentNumber = strings.ToUpper(documentNumber)
+
 	if !CNPJRegex.MatchString(documentNumber) {
 		return "", ErrInvalidCNPJ
 	}
 
-	documentNumber = keepOnlyNumbers(documentNumber)
+	documentNumber = keepOnlyAlphanumerics(documentNumber)
 
 	if len(documentNumber) != 14 {
 		return "", ErrInvalidCNPJ
 	}
 
+	// Each character value is its ASCII code minus 48, so digits keep their value and A is 17
 	var cnpjDigits [14]int
 	for i, char := range documentNumber {
-		digit, err := strconv.Atoi(string(char))
-		if err != nil {
-			return "", ErrInvalidCNPJ
-		}
-		cnpjDigits[i] = digit
+		cnpjDigits[i] = int(char) - '0'
 	}
 
 	sum := 0
@@ -235,3 +306,15 @@ func (c restoredDocument) Value() string {
 func (c restoredDocument) String() string {
 	return c.value
 }
+
+func (c restoredDocument) Masked() string {
+	return maskAllButLast(c.value, 4)
+}
+
+func (restoredDocument) DocumentType() DocumentType {
+	return UnknownDocumentType
+}
+
+func (restoredDocument) PersonKind() PersonKind {
+	return UnknownPersonKind
+}
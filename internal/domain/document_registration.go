@@ -0,0 +1,44 @@
+package domain
+
+import (
+	"errors"
+)
+
+// DocumentRegistrationStatus is the cadastral status of CPF or CNPJ in Receita Federal
+type DocumentRegistrationStatus string
+
+const (
+	RegularRegistrationStatus               DocumentRegistrationStatus = "REGULAR"
+	PendingRegularizationRegistrationStatus DocumentRegistrationStatus = "PENDING_REGULARIZATION"
+	SuspendedRegistrationStatus             DocumentRegistrationStatus = "SUSPENDED"
+	CanceledRegistrationStatus              DocumentRegistrationStatus = "CANCELED"
+	DeceasedHolderRegistrationStatus        DocumentRegistrationStatus = "DECEASED_HOLDER"
+	NullRegistrationStatus                  DocumentRegistrationStatus = "NULL"
+	InaptRegistrationStatus                 DocumentRegistrationStatus = "INAPT"
+	ClosedRegistrationStatus                DocumentRegistrationStatus = "CLOSED"
+)
+
+// DocumentRegistration is the cadastral situation of a document in Receita Federal
+type DocumentRegistration struct {
+	Status       DocumentRegistrationStatus
+	OfficialName string
+}
+
+var (
+	ErrIrregularDocument    = errors.New("document registration status is not regular")
+	ErrOfficialNameMismatch = errors.New("name does not match document official name")
+)
+
+// Verify checks if registration status is REGULAR and official name matches name,
+// ignoring case, accents, extra spaces and the spelling of corporate suffixes
+func (r DocumentRegistration) Verify(name string) error {
+	if r.Status != RegularRegistrationStatus {
+		return ErrIrregularDocument
+	}
+
+	if legalNameSearchKey(r.OfficialName) != legalNameSearchKey(name) {
+		return ErrOfficialNameMismatch
+	}
+
+	return nil
+}
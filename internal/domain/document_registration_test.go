@@ -0,0 +1,76 @@
+package domain_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/italorfeitosa/payee-account-manager-api/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDocumentRegistration_Verify(t *testing.T) {
+	tests := []struct {
+		name         string
+		registration domain.DocumentRegistration
+		payeeName    string
+		wantErr      error
+	}{
+		{
+			name:         "given a regular document with same name should pass",
+			registration: domain.DocumentRegistration{Status: domain.RegularRegistrationStatus, OfficialName: "Italo Feitosa"},
+			payeeName:    "Italo Feitosa",
+		},
+		{
+			name:         "given a regular document with name in upper case without accents should pass",
+			registration: domain.DocumentRegistration{Status: domain.RegularRegistrationStatus, OfficialName: "JOAO  DA CONCEICAO"},
+			payeeName:    "João da Conceição",
+		},
+		{
+			name:         "given a regular document with another name should return error",
+			registration: domain.DocumentRegistration{Status: domain.RegularRegistrationStatus, OfficialName: "MARIA SILVA"},
+			payeeName:    "Italo Feitosa",
+			wantErr:      domain.ErrOfficialNameMismatch,
+		},
+		{
+			name:         "given a suspended document should return error",
+			registration: domain.DocumentRegistration{Status: domain.SuspendedRegistrationStatus, OfficialName: "ITALO FEITOSA"},
+			payeeName:    "Italo Feitosa",
+			wantErr:      domain.ErrIrregularDocument,
+		},
+		{
+			name:         "given a document of deceased holder should return error",
+			registration: domain.DocumentRegistration{Status: domain.DeceasedHolderRegistrationStatus, OfficialName: "ITALO FEITOSA"},
+			payeeName:    "Italo Feitosa",
+			wantErr:      domain.ErrIrregularDocument,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.wantErr, tt.registration.Verify(tt.payeeName))
+		})
+	}
+}
+
+func TestDocumentRegistration_Verify_CorporateSuffixes(t *testing.T) {
+	spellings := []string{"LTDA", "Ltda.", "S.A.", "S.A", "S/A", "SA", "ME", "M.E.", "EPP", "E.P.P.", "EIRELI", "Eireli."}
+	for _, spelling := range spellings {
+		t.Run("given official name with suffix "+spelling+" should match stored legal name", func(t *testing.T) {
+			name, err := domain.NewLegalName("Fake Company " + spelling)
+			require.NoError(t, err)
+
+			registration := domain.DocumentRegistration{
+				Status:       domain.RegularRegistrationStatus,
+				OfficialName: strings.ToUpper("Fake Company " + spelling),
+			}
+
+			assert.NoError(t, registration.Verify(name.Value()))
+		})
+	}
+
+	t.Run("given official name with another suffix should return error", func(t *testing.T) {
+		registration := domain.DocumentRegistration{Status: domain.RegularRegistrationStatus, OfficialName: "FAKE COMPANY S/A"}
+
+		assert.ErrorIs(t, registration.Verify("Fake Company LTDA"), domain.ErrOfficialNameMismatch)
+	})
+}
@@ -81,6 +81,25 @@ func TestNewDocument(t *testing.T) {
 			wantValue:  "01042140000195",
 			wantString: "01.042.140/0001-95",
 		},
+		// Valid alphanumeric CNPJ
+		{
+			name:       "given a valid alphanumeric cnpj should return a document instance",
+			arg:        "12ABC34501DE35",
+			wantValue:  "12ABC34501DE35",
+			wantString: "12.ABC.345/01DE-35",
+		},
+		{
+			name:       "given a valid alphanumeric cnpj formatted should return a document instance",
+			arg:        "12.ABC.345/01DE-35",
+			wantValue:  "12ABC34501DE35",
+			wantString: "12.ABC.345/01DE-35",
+		},
+		{
+			name:       "given a valid alphanumeric cnpj in lower case should return a document instance in upper case",
+			arg:        "12.abc.345/01de-35",
+			wantValue:  "12ABC34501DE35",
+			wantString: "12.ABC.345/01DE-35",
+		},
 		// Invalid CNPJ
 		{
 			name:    "given an invalid cnpj should return a error",
@@ -97,6 +116,16 @@ func TestNewDocument(t *testing.T) {
 			arg:     "63345102000163",
 			wantErr: domain.ErrInvalidDocument,
 		},
+		{
+			name:    "given an alphanumeric cnpj with wrong check digits should return a error",
+			arg:     "12ABC34501DE36",
+			wantErr: domain.ErrInvalidDocument,
+		},
+		{
+			name:    "given an alphanumeric cnpj with letters in check digits should return a error",
+			arg:     "12ABC34501DE3A",
+			wantErr: domain.ErrInvalidDocument,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -111,3 +140,80 @@ func TestNewDocument(t *testing.T) {
 		})
 	}
 }
+
+func TestDocument_Masked(t *testing.T) {
+	tests := []struct {
+		name string
+		arg  string
+		want string
+	}{
+		{
+			name: "given a cpf should hide first and check digits",
+			arg:  "99818083008",
+			want: "***.180.830-**",
+		},
+		{
+			name: "given a cnpj should hide first and check digits",
+			arg:  "19039318000104",
+			want: "**.039.318/0001-**",
+		},
+		{
+			name: "given an alphanumeric cnpj should hide first and check digits",
+			arg:  "12ABC34501DE35",
+			want: "**.ABC.345/01DE-**",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			doc, err := domain.NewDocument(tt.arg)
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, doc.Masked())
+		})
+	}
+}
+
+func TestCNPJ_Structure(t *testing.T) {
+	tests := []struct {
+		arg              string
+		wantRoot         string
+		wantBranchOrder  string
+		wantHeadquarters bool
+	}{
+		{arg: "19.039.318/0001-04", wantRoot: "19039318", wantBranchOrder: "0001", wantHeadquarters: true},
+		{arg: "11.222.333/0002-62", wantRoot: "11222333", wantBranchOrder: "0002", wantHeadquarters: false},
+		{arg: "12.ABC.345/01DE-35", wantRoot: "12ABC345", wantBranchOrder: "01DE", wantHeadquarters: false},
+	}
+	for _, tt := range tests {
+		t.Run("given "+tt.arg+" should return its structure", func(t *testing.T) {
+			cnpj, err := domain.NewCNPJ(tt.arg)
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantRoot, cnpj.Root())
+			assert.Equal(t, tt.wantBranchOrder, cnpj.BranchOrder())
+			assert.Equal(t, tt.wantHeadquarters, cnpj.IsHeadquarters())
+		})
+	}
+}
+
+func TestNewCNPJRoot(t *testing.T) {
+	tests := []struct {
+		arg     string
+		want    string
+		wantErr error
+	}{
+		{arg: "19039318", want: "19039318"},
+		{arg: "19.039.318", want: "19039318"},
+		{arg: "12.abc.345", want: "12ABC345"},
+		{arg: "1903931", wantErr: domain.ErrInvalidCNPJRoot},
+		{arg: "19039318000104", wantErr: domain.ErrInvalidCNPJRoot},
+	}
+	for _, tt := range tests {
+		t.Run("given "+tt.arg, func(t *testing.T) {
+			got, err := domain.NewCNPJRoot(tt.arg)
+
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, tt.wantErr, err)
+		})
+	}
+}
@@ -0,0 +1,50 @@
+package domain
+
+import (
+	"errors"
+	"strings"
+)
+
+// DocumentType discriminates the implementations of Document
+type DocumentType string
+
+const (
+	CPFDocumentType     DocumentType = "CPF"
+	CNPJDocumentType    DocumentType = "CNPJ"
+	UnknownDocumentType DocumentType = "UNKNOWN"
+	// AnonymizedDocumentType is the type of document scrubbed from an anonymized payee
+	AnonymizedDocumentType DocumentType = "ANONYMIZED"
+)
+
+// PersonKind returns NATURAL for CPF, LEGAL for CNPJ and UNKNOWN otherwise
+func (t DocumentType) PersonKind() PersonKind {
+	switch t {
+	case CPFDocumentType:
+		return NaturalPersonKind
+	case CNPJDocumentType:
+		return LegalPersonKind
+	default:
+		return UnknownPersonKind
+	}
+}
+
+// PersonKind tells if the document holder is a natural person (pessoa física) or a legal person (pessoa jurídica)
+type PersonKind string
+
+const (
+	NaturalPersonKind PersonKind = "NATURAL"
+	LegalPersonKind   PersonKind = "LEGAL"
+	UnknownPersonKind PersonKind = "UNKNOWN"
+)
+
+var ErrInvalidPersonKind = errors.New("invalid person kind")
+
+// NewPersonKind returns NATURAL or LEGAL person kind, case insensitive
+func NewPersonKind(v string) (PersonKind, error) {
+	switch kind := PersonKind(strings.ToUpper(strings.TrimSpace(v))); kind {
+	case NaturalPersonKind, LegalPersonKind:
+		return kind, nil
+	default:
+		return "", ErrInvalidPersonKind
+	}
+}
@@ -0,0 +1,78 @@
+package domain_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/italorfeitosa/payee-account-manager-api/internal/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDocument_DocumentType(t *testing.T) {
+	tests := []struct {
+		name           string
+		arg            string
+		wantType       domain.DocumentType
+		wantPersonKind domain.PersonKind
+	}{
+		{
+			name:           "given a cpf should be a natural person",
+			arg:            "99818083008",
+			wantType:       domain.CPFDocumentType,
+			wantPersonKind: domain.NaturalPersonKind,
+		},
+		{
+			name:           "given a cnpj should be a legal person",
+			arg:            "19039318000104",
+			wantType:       domain.CNPJDocumentType,
+			wantPersonKind: domain.LegalPersonKind,
+		},
+		{
+			name:           "given an alphanumeric cnpj should be a legal person",
+			arg:            "12ABC34501DE35",
+			wantType:       domain.CNPJDocumentType,
+			wantPersonKind: domain.LegalPersonKind,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			doc, err := domain.NewDocument(tt.arg)
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantType, doc.DocumentType())
+			assert.Equal(t, tt.wantPersonKind, doc.PersonKind())
+		})
+	}
+
+	t.Run("given a tempered document should be unknown", func(t *testing.T) {
+		payee := domain.RestorePayee(
+			context.Background(),
+			domain.NewEntityID().Value(), "Italo Feitosa", "123", domain.PayeeDraftStatus.Value(),
+			"", domain.CPFPixKeyType, "99818083008", nil,
+		)
+
+		assert.Equal(t, domain.UnknownDocumentType, payee.DocumentType())
+		assert.Equal(t, domain.UnknownPersonKind, payee.PersonKind())
+	})
+}
+
+func TestNewPersonKind(t *testing.T) {
+	tests := []struct {
+		arg     string
+		want    domain.PersonKind
+		wantErr error
+	}{
+		{arg: "NATURAL", want: domain.NaturalPersonKind},
+		{arg: "legal", want: domain.LegalPersonKind},
+		{arg: "UNKNOWN", wantErr: domain.ErrInvalidPersonKind},
+		{arg: "", wantErr: domain.ErrInvalidPersonKind},
+	}
+	for _, tt := range tests {
+		t.Run("given "+tt.arg, func(t *testing.T) {
+			got, err := domain.NewPersonKind(tt.arg)
+
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, tt.wantErr, err)
+		})
+	}
+}
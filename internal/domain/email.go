@@ -16,6 +16,16 @@ func (e Email) Value() string {
 	return e.value
 }
 
+// Masked returns the email address keeping only the first character of local part and the domain (Ex: i***@feitosa.com)
+func (e Email) Masked() string {
+	local, domain, found := strings.Cut(e.value, "@")
+	if !found || local == "" {
+		return e.value
+	}
+
+	return local[:1] + "***@" + domain
+}
+
 var (
 	EmptyEmail Email
 
@@ -64,3 +64,11 @@ func TestNewEmail(t *testing.T) {
 		})
 	}
 }
+
+func TestEmail_Masked(t *testing.T) {
+	email, err := domain.NewEmail("italo@feitosa.com")
+
+	assert.NoError(t, err)
+	assert.Equal(t, "i***@feitosa.com", email.Masked())
+	assert.Equal(t, "", domain.EmptyEmail.Masked())
+}
@@ -0,0 +1,460 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"slices"
+	"strings"
+)
+
+// InscricaoEstadual is value object that represents the state registration of a company,
+// its check digits follow the algorithm of the federative unit that issued it
+type InscricaoEstadual struct {
+	uf    UF
+	value string
+}
+
+// ExemptInscricaoEstadual is the value of companies exempt from state registration
+const ExemptInscricaoEstadual = "ISENTO"
+
+// UF returns the federative unit that issued the state registration
+func (ie InscricaoEstadual) UF() UF {
+	return ie.uf
+}
+
+// Value returns the state registration without separators, or ISENTO
+func (ie InscricaoEstadual) Value() string {
+	return ie.value
+}
+
+// Exempt reports if company is exempt from state registration
+func (ie InscricaoEstadual) Exempt() bool {
+	return ie.value == ExemptInscricaoEstadual
+}
+
+var (
+	EmptyInscricaoEstadual InscricaoEstadual
+
+	ErrInvalidInscricaoEstadual = errors.New("invalid inscricao estadual")
+
+	// inscricaoEstadualSeparators are removed by NormalizeInscricaoEstadual
+	inscricaoEstadualSeparators = strings.NewReplacer(" ", "", ".", "", "-", "", "/", "")
+)
+
+// NormalizeInscricaoEstadual returns value in the stored format, upper case and without separators,
+// so formatted searches match stored state registrations
+func NormalizeInscricaoEstadual(value string) string {
+	return strings.ToUpper(inscricaoEstadualSeparators.Replace(strings.TrimSpace(value)))
+}
+
+// NewInscricaoEstadual creates a new instance of InscricaoEstadual issued by uf,
+// value can be formatted or ISENTO
+func NewInscricaoEstadual(uf, value string) (InscricaoEstadual, error) {
+	stateUF, err := NewUF(uf)
+	if err != nil {
+		return EmptyInscricaoEstadual, err
+	}
+
+	value = NormalizeInscricaoEstadual(value)
+
+	if value == ExemptInscricaoEstadual {
+		return InscricaoEstadual{stateUF, value}, nil
+	}
+
+	// São Paulo rural producers registration starts with P
+	digits := strings.TrimPrefix(value, "P")
+	if digits != value && stateUF != UFSP {
+		return EmptyInscricaoEstadual, ErrInvalidInscricaoEstadual
+	}
+
+	if digits == "" || keepOnlyNumbers(digits) != digits {
+		return EmptyInscricaoEstadual, ErrInvalidInscricaoEstadual
+	}
+
+	validate := inscricaoEstadualValidators[stateUF]
+	if digits != value {
+		validate = validateInscricaoEstadualSPRural
+	}
+
+	if !validate(digits) {
+		return EmptyInscricaoEstadual, ErrInvalidInscricaoEstadual
+	}
+
+	return InscricaoEstadual{stateUF, value}, nil
+}
+
+// restoreInscricaoEstadual keeps state registration restored from database as is, logging it when tempered
+func restoreInscricaoEstadual(ctx context.Context, ie InscricaoEstadual, attrs ...slog.Attr) InscricaoEstadual {
+	if _, err := NewInscricaoEstadual(ie.uf.String(), ie.value); err != nil {
+		attrs = append(attrs, slog.String("uf", ie.uf.String()), slog.String("error", err.Error()))
+		slog.LogAttrs(ctx, slog.LevelWarn, "tempered inscricao estadual with invalid values", attrs...)
+	}
+
+	return ie
+}
+
+// inscricaoEstadualValidators validates only numbers state registrations, as published by Sintegra
+var inscricaoEstadualValidators = map[UF]func(digits string) bool{
+	UFAC: validateInscricaoEstadualAC,
+	UFAL: validateInscricaoEstadualAL,
+	UFAP: validateInscricaoEstadualAP,
+	UFAM: validateInscricaoEstadualAM,
+	UFBA: validateInscricaoEstadualBA,
+	UFCE: validateInscricaoEstadualMod11,
+	UFDF: validateInscricaoEstadualDF,
+	UFES: validateInscricaoEstadualMod11,
+	UFGO: validateInscricaoEstadualGO,
+	UFMA: validateInscricaoEstadualMA,
+	UFMT: validateInscricaoEstadualMT,
+	UFMS: validateInscricaoEstadualMS,
+	UFMG: validateInscricaoEstadualMG,
+	UFPA: validateInscricaoEstadualPA,
+	UFPB: validateInscricaoEstadualMod11,
+	UFPR: validateInscricaoEstadualPR,
+	UFPE: validateInscricaoEstadualPE,
+	UFPI: validateInscricaoEstadualMod11,
+	UFRJ: validateInscricaoEstadualRJ,
+	UFRN: validateInscricaoEstadualRN,
+	UFRS: validateInscricaoEstadualRS,
+	UFRO: validateInscricaoEstadualRO,
+	UFRR: validateInscricaoEstadualRR,
+	UFSC: validateInscricaoEstadualMod11,
+	UFSP: validateInscricaoEstadualSP,
+	UFSE: validateInscricaoEstadualMod11,
+	UFTO: validateInscricaoEstadualTO,
+}
+
+var (
+	weights9To2  = []int{9, 8, 7, 6, 5, 4, 3, 2}
+	weights10To2 = []int{10, 9, 8, 7, 6, 5, 4, 3, 2}
+)
+
+// weightedSum multiplies the first digits by the weight at same position, digits must be as long as weights
+func weightedSum(digits string, weights []int) int {
+	sum := 0
+	for i, weight := range weights {
+		sum += int(digits[i]-'0') * weight
+	}
+
+	return sum
+}
+
+// mod11Digit returns 11 minus the remainder of sum by 11, or 0 when it would be 10 or 11
+func mod11Digit(sum int) int {
+	digit := 11 - sum%11
+	if digit >= 10 {
+		return 0
+	}
+
+	return digit
+}
+
+// hasDigit reports if digit at position i of digits is want
+func hasDigit(digits string, i int, want int) bool {
+	return int(digits[i]-'0') == want
+}
+
+// validateInscricaoEstadualMod11 validates 9 digits registrations with weights 9 to 2 and mod11Digit,
+// used by CE, ES, PB, PI, SC and SE
+func validateInscricaoEstadualMod11(digits string) bool {
+	return len(digits) == 9 && hasDigit(digits, 8, mod11Digit(weightedSum(digits, weights9To2)))
+}
+
+func validateInscricaoEstadualAC(digits string) bool {
+	if len(digits) != 13 || !strings.HasPrefix(digits, "01") {
+		return false
+	}
+
+	first := mod11Digit(weightedSum(digits, []int{4, 3, 2, 9, 8, 7, 6, 5, 4, 3, 2}))
+	second := mod11Digit(weightedSum(digits, []int{5, 4, 3, 2, 9, 8, 7, 6, 5, 4, 3, 2}))
+
+	return hasDigit(digits, 11, first) && hasDigit(digits, 12, second)
+}
+
+func validateInscricaoEstadualAL(digits string) bool {
+	if len(digits) != 9 || !strings.HasPrefix(digits, "24") {
+		return false
+	}
+
+	digit := weightedSum(digits, weights9To2) * 10 % 11
+	if digit == 10 {
+		digit = 0
+	}
+
+	return hasDigit(digits, 8, digit)
+}
+
+func validateInscricaoEstadualAP(digits string) bool {
+	if len(digits) != 9 || !strings.HasPrefix(digits, "03") {
+		return false
+	}
+
+	// p is added to sum and d replaces the digit 11, by number range
+	p, d := 0, 0
+	switch number := digits[:8]; {
+	case number <= "03017000":
+		p, d = 5, 0
+	case number <= "03019022":
+		p, d = 9, 1
+	}
+
+	digit := 11 - (p+weightedSum(digits, weights9To2))%11
+	switch digit {
+	case 10:
+		digit = 0
+	case 11:
+		digit = d
+	}
+
+	return hasDigit(digits, 8, digit)
+}
+
+func validateInscricaoEstadualAM(digits string) bool {
+	if len(digits) != 9 {
+		return false
+	}
+
+	sum := weightedSum(digits, weights9To2)
+
+	digit := 0
+	if sum < 11 {
+		digit = 11 - sum
+	} else if remainder := sum % 11; remainder > 1 {
+		digit = 11 - remainder
+	}
+
+	return hasDigit(digits, 8, digit)
+}
+
+// validateInscricaoEstadualBA validates 8 or 9 digits registrations, the second check digit is
+// calculated first, modulo is 10 or 11 by the first digit (8 digits) or second digit (9 digits)
+func validateInscricaoEstadualBA(digits string) bool {
+	if len(digits) != 8 && len(digits) != 9 {
+		return false
+	}
+
+	base := digits[:len(digits)-2]
+
+	moduloDigit := digits[0]
+	if len(digits) == 9 {
+		moduloDigit = digits[1]
+	}
+
+	checkDigit := func(sum int) int {
+		if strings.ContainsRune("679", rune(moduloDigit)) {
+			if remainder := sum % 11; remainder > 1 {
+				return 11 - remainder
+			}
+
+			return 0
+		}
+
+		if remainder := sum % 10; remainder > 0 {
+			return 10 - remainder
+		}
+
+		return 0
+	}
+
+	weights := weights10To2[len(weights10To2)-len(base)-1:]
+
+	second := checkDigit(weightedSum(base, weights[1:]))
+	first := checkDigit(weightedSum(base+string(rune('0'+second)), weights))
+
+	return hasDigit(digits, len(digits)-2, first) && hasDigit(digits, len(digits)-1, second)
+}
+
+func validateInscricaoEstadualDF(digits string) bool {
+	if len(digits) != 13 || !strings.HasPrefix(digits, "07") && !strings.HasPrefix(digits, "08") {
+		return false
+	}
+
+	first := mod11Digit(weightedSum(digits, []int{4, 3, 2, 9, 8, 7, 6, 5, 4, 3, 2}))
+	second := mod11Digit(weightedSum(digits, []int{5, 4, 3, 2, 9, 8, 7, 6, 5, 4, 3, 2}))
+
+	return hasDigit(digits, 11, first) && hasDigit(digits, 12, second)
+}
+
+func validateInscricaoEstadualGO(digits string) bool {
+	if len(digits) != 9 || digits[0] != '1' && digits[0] != '2' {
+		return false
+	}
+
+	number := digits[:8]
+
+	// number 11094402 is accepted with check digit 0 or 1
+	if number == "11094402" {
+		return digits[8] == '0' || digits[8] == '1'
+	}
+
+	digit := 0
+	switch remainder := weightedSum(digits, weights9To2) % 11; {
+	case remainder == 1 && number >= "10103105" && number <= "10119997":
+		digit = 1
+	case remainder > 1:
+		digit = 11 - remainder
+	}
+
+	return hasDigit(digits, 8, digit)
+}
+
+func validateInscricaoEstadualMA(digits string) bool {
+	return len(digits) == 9 && strings.HasPrefix(digits, "12") && validateInscricaoEstadualMod11(digits)
+}
+
+// validateInscricaoEstadualMT validates registrations up to 11 digits, shorter ones are left padded with zeros
+func validateInscricaoEstadualMT(digits string) bool {
+	if len(digits) > 11 || len(digits) < 9 {
+		return false
+	}
+
+	digits = strings.Repeat("0", 11-len(digits)) + digits
+
+	return hasDigit(digits, 10, mod11Digit(weightedSum(digits, []int{3, 2, 9, 8, 7, 6, 5, 4, 3, 2})))
+}
+
+func validateInscricaoEstadualMS(digits string) bool {
+	if len(digits) != 9 || !strings.HasPrefix(digits, "28") && !strings.HasPrefix(digits, "50") {
+		return false
+	}
+
+	return validateInscricaoEstadualMod11(digits)
+}
+
+// validateInscricaoEstadualMG validates 13 digits registrations, the first check digit is a modulo 10
+// of the digits sum of products, after inserting a zero after the municipality code
+func validateInscricaoEstadualMG(digits string) bool {
+	if len(digits) != 13 {
+		return false
+	}
+
+	padded := digits[:3] + "0" + digits[3:11]
+
+	sum := 0
+	for i := range padded {
+		product := int(padded[i]-'0') * (i%2 + 1)
+		sum += product/10 + product%10
+	}
+
+	first := (10 - sum%10) % 10
+	second := mod11Digit(weightedSum(digits, []int{3, 2, 11, 10, 9, 8, 7, 6, 5, 4, 3, 2}))
+
+	return hasDigit(digits, 11, first) && hasDigit(digits, 12, second)
+}
+
+func validateInscricaoEstadualPA(digits string) bool {
+	return len(digits) == 9 && strings.HasPrefix(digits, "15") && validateInscricaoEstadualMod11(digits)
+}
+
+func validateInscricaoEstadualPR(digits string) bool {
+	if len(digits) != 10 {
+		return false
+	}
+
+	first := mod11Digit(weightedSum(digits, []int{3, 2, 7, 6, 5, 4, 3, 2}))
+	second := mod11Digit(weightedSum(digits, []int{4, 3, 2, 7, 6, 5, 4, 3, 2}))
+
+	return hasDigit(digits, 8, first) && hasDigit(digits, 9, second)
+}
+
+// validateInscricaoEstadualPE validates 9 digits registrations (eFisco) and 14 digits ones (previous format)
+func validateInscricaoEstadualPE(digits string) bool {
+	switch len(digits) {
+	case 9:
+		first := mod11Digit(weightedSum(digits, []int{8, 7, 6, 5, 4, 3, 2}))
+		second := mod11Digit(weightedSum(digits, weights9To2))
+
+		return hasDigit(digits, 7, first) && hasDigit(digits, 8, second)
+	case 14:
+		digit := 11 - weightedSum(digits, []int{5, 4, 3, 2, 1, 9, 8, 7, 6, 5, 4, 3, 2})%11
+		if digit > 9 {
+			digit -= 10
+		}
+
+		return hasDigit(digits, 13, digit)
+	default:
+		return false
+	}
+}
+
+func validateInscricaoEstadualRJ(digits string) bool {
+	return len(digits) == 8 && hasDigit(digits, 7, mod11Digit(weightedSum(digits, []int{2, 7, 6, 5, 4, 3, 2})))
+}
+
+// validateInscricaoEstadualRN validates 9 or 10 digits registrations
+func validateInscricaoEstadualRN(digits string) bool {
+	if len(digits) != 9 && len(digits) != 10 || !strings.HasPrefix(digits, "20") {
+		return false
+	}
+
+	weights := weights10To2[10-len(digits):]
+
+	digit := weightedSum(digits, weights) * 10 % 11
+	if digit == 10 {
+		digit = 0
+	}
+
+	return hasDigit(digits, len(digits)-1, digit)
+}
+
+func validateInscricaoEstadualRS(digits string) bool {
+	return len(digits) == 10 && hasDigit(digits, 9, mod11Digit(weightedSum(digits, []int{2, 9, 8, 7, 6, 5, 4, 3, 2})))
+}
+
+// validateInscricaoEstadualRO validates 14 digits registrations, issued since August 2000
+func validateInscricaoEstadualRO(digits string) bool {
+	if len(digits) != 14 {
+		return false
+	}
+
+	digit := 11 - weightedSum(digits, []int{6, 5, 4, 3, 2, 9, 8, 7, 6, 5, 4, 3, 2})%11
+	if digit >= 10 {
+		digit -= 10
+	}
+
+	return hasDigit(digits, 13, digit)
+}
+
+func validateInscricaoEstadualRR(digits string) bool {
+	if len(digits) != 9 || !strings.HasPrefix(digits, "24") {
+		return false
+	}
+
+	return hasDigit(digits, 8, weightedSum(digits, []int{1, 2, 3, 4, 5, 6, 7, 8})%9)
+}
+
+// validateInscricaoEstadualSP validates 12 digits registrations of industry and commerce,
+// check digits are the rightmost digit of the remainder by 11
+func validateInscricaoEstadualSP(digits string) bool {
+	if len(digits) != 12 {
+		return false
+	}
+
+	first := weightedSum(digits, []int{1, 3, 4, 5, 6, 7, 8, 10}) % 11 % 10
+	second := weightedSum(digits, []int{3, 2, 10, 9, 8, 7, 6, 5, 4, 3, 2}) % 11 % 10
+
+	return hasDigit(digits, 8, first) && hasDigit(digits, 11, second)
+}
+
+// validateInscricaoEstadualSPRural validates the 12 digits after P of rural producers registration
+func validateInscricaoEstadualSPRural(digits string) bool {
+	return len(digits) == 12 && hasDigit(digits, 8, weightedSum(digits, []int{1, 3, 4, 5, 6, 7, 8, 10})%11%10)
+}
+
+// validateInscricaoEstadualTO validates 9 digits registrations and 11 digits ones (previous format),
+// which have the company type at third and fourth digits, ignored in calculation
+func validateInscricaoEstadualTO(digits string) bool {
+	switch len(digits) {
+	case 9:
+		return validateInscricaoEstadualMod11(digits)
+	case 11:
+		if !slices.Contains([]string{"01", "02", "03", "99"}, digits[2:4]) {
+			return false
+		}
+
+		return validateInscricaoEstadualMod11(digits[:2] + digits[4:])
+	default:
+		return false
+	}
+}
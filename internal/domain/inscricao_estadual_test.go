@@ -0,0 +1,110 @@
+package domain_test
+
+import (
+	"testing"
+
+	"github.com/italorfeitosa/payee-account-manager-api/internal/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewInscricaoEstadual(t *testing.T) {
+	// valid examples published by Sintegra for each federative unit
+	valid := []struct {
+		uf    string
+		value string
+		want  string
+	}{
+		{"AC", "01.004.823/001-12", "0100482300112"},
+		{"AL", "240000048", "240000048"},
+		{"AP", "030123459", "030123459"},
+		{"AM", "99.999.999-0", "999999990"},
+		{"BA", "123456-63", "12345663"},
+		{"BA", "612345-57", "61234557"},
+		{"BA", "1000003-06", "100000306"},
+		{"CE", "06000001-5", "060000015"},
+		{"DF", "07300001001-09", "0730000100109"},
+		{"ES", "999999990", "999999990"},
+		{"GO", "10.987.654-7", "109876547"},
+		{"MA", "120000385", "120000385"},
+		{"MT", "0013000001-9", "00130000019"},
+		{"MS", "283115947", "283115947"},
+		{"MG", "062.307.904/0081", "0623079040081"},
+		{"PA", "15-999999-5", "159999995"},
+		{"PB", "06000001-5", "060000015"},
+		{"PR", "123.45678-50", "1234567850"},
+		{"PE", "0321418-40", "032141840"},
+		{"PE", "18.1.001.0000004-9", "18100100000049"},
+		{"PI", "012345679", "012345679"},
+		{"RJ", "99.999.99-3", "99999993"},
+		{"RN", "20.040.040-1", "200400401"},
+		{"RN", "20.0.040.040-0", "2000400400"},
+		{"RS", "224/3658792", "2243658792"},
+		{"RO", "0000000062521-3", "00000000625213"},
+		{"RR", "24006628-1", "240066281"},
+		{"SC", "251.040.852", "251040852"},
+		{"SP", "110.042.490.114", "110042490114"},
+		{"SP", "P-01100424.3/002", "P011004243002"},
+		{"SE", "27123456-3", "271234563"},
+		{"TO", "29.01.022783-6", "29010227836"},
+		{"TO", "29.022783-6", "290227836"},
+	}
+	for _, tt := range valid {
+		t.Run("given a valid inscricao estadual of "+tt.uf+" should return InscricaoEstadual", func(t *testing.T) {
+			got, err := domain.NewInscricaoEstadual(tt.uf, tt.value)
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got.Value())
+			assert.Equal(t, domain.UF(tt.uf), got.UF())
+			assert.False(t, got.Exempt())
+		})
+	}
+
+	t.Run("given ISENTO should return an exempt InscricaoEstadual", func(t *testing.T) {
+		got, err := domain.NewInscricaoEstadual("sp", "Isento")
+
+		assert.NoError(t, err)
+		assert.Equal(t, "ISENTO", got.Value())
+		assert.True(t, got.Exempt())
+	})
+
+	invalid := []struct {
+		name    string
+		uf      string
+		value   string
+		wantErr error
+	}{
+		{"given a wrong check digit of SP", "SP", "110.042.490.115", domain.ErrInvalidInscricaoEstadual},
+		{"given a wrong first check digit of MG", "MG", "0623079040071", domain.ErrInvalidInscricaoEstadual},
+		{"given a wrong check digit of RJ", "RJ", "99.999.99-4", domain.ErrInvalidInscricaoEstadual},
+		{"given a valid inscricao estadual of another uf", "SC", "110.042.490.114", domain.ErrInvalidInscricaoEstadual},
+		{"given a rural producer out of SP", "PR", "P-01100424.3/002", domain.ErrInvalidInscricaoEstadual},
+		{"given letters", "SP", "ABC", domain.ErrInvalidInscricaoEstadual},
+		{"given an empty value", "SP", "", domain.ErrInvalidInscricaoEstadual},
+		{"given an invalid uf", "XX", "110.042.490.114", domain.ErrInvalidUF},
+	}
+	for _, tt := range invalid {
+		t.Run(tt.name+" should return error", func(t *testing.T) {
+			got, err := domain.NewInscricaoEstadual(tt.uf, tt.value)
+
+			assert.Equal(t, domain.EmptyInscricaoEstadual, got)
+			assert.ErrorIs(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestNormalizeInscricaoEstadual(t *testing.T) {
+	tests := []struct {
+		value string
+		want  string
+	}{
+		{"110.042.490.114", "110042490114"},
+		{" 110 042 490 114 ", "110042490114"},
+		{"p-01100424.3/002", "P011004243002"},
+		{"isento", "ISENTO"},
+	}
+	for _, tt := range tests {
+		t.Run("given "+tt.value+" should return it without separators", func(t *testing.T) {
+			assert.Equal(t, tt.want, domain.NormalizeInscricaoEstadual(tt.value))
+		})
+	}
+}
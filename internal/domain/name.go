@@ -3,6 +3,13 @@ package domain
 import (
 	"errors"
 	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"golang.org/x/text/cases"
+	"golang.org/x/text/runes"
+	"golang.org/x/text/transform"
+	"golang.org/x/text/unicode/norm"
 )
 
 // Name is value object that represents a Person Name or Legal Name
@@ -15,6 +22,29 @@ func (n Name) Value() string {
 	return n.value
 }
 
+// SearchKey returns the normalized form of Name used to search, see NameSearchKey
+func (n Name) SearchKey() string {
+	return NameSearchKey(n.value)
+}
+
+// NameSearchKey removes diacritics, folds case and collapses whitespace of v,
+// to compare names and search terms (Ex: " João  FEITOSA" returns "joao feitosa")
+func NameSearchKey(v string) string {
+	normalize := transform.Chain(norm.NFD, runes.Remove(runes.In(unicode.Mn)), norm.NFC, cases.Fold())
+
+	folded, _, err := transform.String(normalize, v)
+	if err != nil {
+		folded = strings.ToLower(v)
+	}
+
+	return strings.Join(strings.Fields(folded), " ")
+}
+
+const (
+	NameMinLength = 2
+	NameMaxLength = 128
+)
+
 var (
 	// EmptyName is zero value of Name, can help in assertions
 	EmptyName Name
@@ -22,14 +52,32 @@ var (
 	ErrNameEmptyString      = errors.New("name cannot be empty")
 	ErrNameLessThenTwoWords = errors.New("name must contain at least two words")
 	ErrShortFirstName       = errors.New("first name must have at least two characters")
+	ErrNameTooShort         = errors.New("name must have at least 2 characters")
+	ErrNameTooLong          = errors.New("name must have at most 128 characters")
+	ErrNameOnlySuffix       = errors.New("legal name cannot be only a corporate suffix")
 )
 
-// NewName creates a new instance of Name value object, return error if name value is not valid
-func NewName(v string) (Name, error) {
-	trimmedName := strings.Join(strings.Fields(v), " ")
+// corporateSuffixes maps the spellings of corporate suffixes to their normalized form
+var corporateSuffixes = map[string]string{
+	"LTDA":    "LTDA",
+	"LTDA.":   "LTDA",
+	"S.A.":    "S.A.",
+	"S.A":     "S.A.",
+	"S/A":     "S.A.",
+	"SA":      "S.A.",
+	"ME":      "ME",
+	"M.E.":    "ME",
+	"EPP":     "EPP",
+	"E.P.P.":  "EPP",
+	"EIRELI":  "EIRELI",
+	"EIRELI.": "EIRELI",
+}
 
-	if trimmedName == "" {
-		return EmptyName, ErrNameEmptyString
+// NewName creates a new instance of a natural person Name value object, return error if name value is not valid
+func NewName(v string) (Name, error) {
+	trimmedName, err := trimName(v)
+	if err != nil {
+		return EmptyName, err
 	}
 
 	words := strings.Split(trimmedName, " ")
@@ -38,9 +86,81 @@ func NewName(v string) (Name, error) {
 		return EmptyName, ErrNameLessThenTwoWords
 	}
 
-	if len(words[0]) < 2 {
+	if utf8.RuneCountInString(words[0]) < 2 {
 		return EmptyName, ErrShortFirstName
 	}
 
 	return Name{trimmedName}, nil
 }
+
+// NewLegalName creates a new instance of a legal entity Name value object, a single word is accepted
+// and trailing corporate suffixes are normalized (Ex: "Fake Company Ltda." becomes "Fake Company LTDA")
+func NewLegalName(v string) (Name, error) {
+	trimmedName, err := trimName(v)
+	if err != nil {
+		return EmptyName, err
+	}
+
+	words := strings.Split(trimmedName, " ")
+
+	if normalizeCorporateSuffixes(words) < 0 {
+		return EmptyName, ErrNameOnlySuffix
+	}
+
+	return Name{strings.Join(words, " ")}, nil
+}
+
+// normalizeCorporateSuffixes rewrites the trailing corporate suffixes of words to their normalized form,
+// returning the index of the last word that is not a suffix, -1 when all words are suffixes
+func normalizeCorporateSuffixes(words []string) int {
+	i := len(words) - 1
+	for ; i >= 0; i-- {
+		suffix, ok := corporateSuffixes[strings.ToUpper(words[i])]
+		if !ok {
+			break
+		}
+
+		words[i] = suffix
+	}
+
+	return i
+}
+
+// legalNameSearchKey returns NameSearchKey of v with corporate suffixes normalized as in NewLegalName,
+// so a stored legal name matches its other spellings (Ex: "Fake Company S.A." and "FAKE COMPANY S/A")
+func legalNameSearchKey(v string) string {
+	words := strings.Fields(v)
+	normalizeCorporateSuffixes(words)
+
+	return NameSearchKey(strings.Join(words, " "))
+}
+
+// NewNameFor creates a Name following the rules of person kind, LEGAL for companies, otherwise NATURAL
+func NewNameFor(v string, kind PersonKind) (Name, error) {
+	if kind == LegalPersonKind {
+		return NewLegalName(v)
+	}
+
+	return NewName(v)
+}
+
+// trimName removes extra spaces and checks the name length in characters
+func trimName(v string) (string, error) {
+	trimmedName := strings.Join(strings.Fields(v), " ")
+
+	if trimmedName == "" {
+		return "", ErrNameEmptyString
+	}
+
+	length := utf8.RuneCountInString(trimmedName)
+
+	if length < NameMinLength {
+		return "", ErrNameTooShort
+	}
+
+	if length > NameMaxLength {
+		return "", ErrNameTooLong
+	}
+
+	return trimmedName, nil
+}
@@ -1,10 +1,12 @@
 package domain_test
 
 import (
+	"strings"
 	"testing"
 
 	"github.com/italorfeitosa/payee-account-manager-api/internal/domain"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestNewName(t *testing.T) {
@@ -44,6 +46,26 @@ func TestNewName(t *testing.T) {
 			arg:     "",
 			wantErr: domain.ErrNameEmptyString,
 		},
+		{
+			name: "given a name when first name has multi-byte characters should count characters",
+			arg:  "Ícaro Souza",
+			want: "Ícaro Souza",
+		},
+		{
+			name:    "given a name when first name is a single multi-byte character should return error",
+			arg:     "É Souza",
+			wantErr: domain.ErrShortFirstName,
+		},
+		{
+			name: "given a name with 128 multi-byte characters should return a valid Name",
+			arg:  "Jo " + strings.Repeat("ã", 125),
+			want: "Jo " + strings.Repeat("ã", 125),
+		},
+		{
+			name:    "given a name longer than 128 characters should return error",
+			arg:     "Jo " + strings.Repeat("a", 126),
+			wantErr: domain.ErrNameTooLong,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -58,3 +80,106 @@ func TestNewName(t *testing.T) {
 		})
 	}
 }
+
+func TestNewLegalName(t *testing.T) {
+	tests := []struct {
+		name    string
+		arg     string
+		want    string
+		wantErr error
+	}{
+		{
+			name: "given a single word company name should return a valid Name",
+			arg:  "Nubank",
+			want: "Nubank",
+		},
+		{
+			name: "given a company name with lower case suffix should normalize it",
+			arg:  "The Fake Company ltda.",
+			want: "The Fake Company LTDA",
+		},
+		{
+			name: "given a company name with S/A suffix should normalize it",
+			arg:  "Banco  Fake s/a",
+			want: "Banco Fake S.A.",
+		},
+		{
+			name: "given a company name with many suffixes should normalize all of them",
+			arg:  "Padaria Pão Quente Ltda m.e.",
+			want: "Padaria Pão Quente LTDA ME",
+		},
+		{
+			name: "given a company name with Eireli suffix should normalize it",
+			arg:  "Fake Eireli",
+			want: "Fake EIRELI",
+		},
+		{
+			name: "given a company name with suffix in the middle should keep it",
+			arg:  "Sa Comercio",
+			want: "Sa Comercio",
+		},
+		{
+			name:    "given only a corporate suffix should return error",
+			arg:     "Ltda",
+			wantErr: domain.ErrNameOnlySuffix,
+		},
+		{
+			name:    "given a single character name should return error",
+			arg:     "X",
+			wantErr: domain.ErrNameTooShort,
+		},
+		{
+			name:    "given a name longer than 128 characters should return error",
+			arg:     strings.Repeat("ç", 129),
+			wantErr: domain.ErrNameTooLong,
+		},
+		{
+			name:    "given an empty name should return error",
+			arg:     "   ",
+			wantErr: domain.ErrNameEmptyString,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			name, err := domain.NewLegalName(tt.arg)
+			if tt.wantErr != nil {
+				assert.Equal(t, domain.EmptyName, name)
+				assert.Equal(t, tt.wantErr, err)
+			} else {
+				assert.Equal(t, tt.want, name.Value())
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestName_SearchKey(t *testing.T) {
+	tests := []struct {
+		name string
+		arg  string
+		want string
+	}{
+		{
+			name: "given a name with diacritics should remove them",
+			arg:  "João Conceição",
+			want: "joao conceicao",
+		},
+		{
+			name: "given a legal name with upper case suffix should fold case",
+			arg:  "Padaria Pão Quente LTDA",
+			want: "padaria pao quente ltda",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			name, err := domain.NewLegalName(tt.arg)
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, name.SearchKey())
+		})
+	}
+
+	t.Run("given a search term with extra spaces should collapse them", func(t *testing.T) {
+		assert.Equal(t, "joao feitosa", domain.NameSearchKey("  JOÃO   Feitosa "))
+	})
+}
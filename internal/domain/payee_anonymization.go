@@ -0,0 +1,100 @@
+package domain
+
+import (
+	"errors"
+	"time"
+)
+
+// ErrPayeeAnonymized is returned when an anonymized payee is changed, its personal data can't be set again
+var ErrPayeeAnonymized = errors.New("payee is anonymized")
+
+// Anonymized reports if personal data of payee was scrubbed
+func (p *PayeeEntity) Anonymized() bool {
+	return p.anonymizedAt != nil
+}
+
+// AnonymizedAt returns when payee was anonymized, nil when it is not anonymized
+func (p *PayeeEntity) AnonymizedAt() *time.Time {
+	return p.anonymizedAt
+}
+
+// Anonymize irreversibly scrubs the personal data of payee, as requested by its data subject.
+// Id, status, timestamps and the bank of bank account are kept for accounting,
+// document and primary pix key are replaced by anonymized placeholders
+func (p *PayeeEntity) Anonymize() {
+	if p.anonymizedAt != nil {
+		return
+	}
+
+	now := time.Now().UTC()
+
+	p.name = EmptyName
+	p.tradeName = EmptyName
+	p.inscricaoEstadual = nil
+	p.document = anonymizedDocument{}
+	p.email = EmptyEmail
+	p.emailVerifiedAt = nil
+	p.address = nil
+	p.contacts = nil
+	p.pixKeys = []PixKey{anonymizedPixKey{}}
+
+	if p.bankAccount != nil {
+		bankAccount := *p.bankAccount
+		bankAccount.AccountNumber = ""
+		bankAccount.AccountDigit = ""
+		p.bankAccount = &bankAccount
+	}
+
+	p.anonymizedAt = &now
+	p.updatedAt = now
+}
+
+// RestoreAnonymizedAt restores when payee was anonymized, nil means not anonymized.
+// Document and primary pix key of an anonymized payee are restored as placeholders, without validation
+func RestoreAnonymizedAt(anonymizedAt *time.Time) RestoreOption {
+	return func(p *PayeeEntity) {
+		p.anonymizedAt = anonymizedAt
+	}
+}
+
+// anonymizedDocument is the document of an anonymized payee, it has no value
+type anonymizedDocument struct{}
+
+func (anonymizedDocument) Value() string {
+	return ""
+}
+
+func (anonymizedDocument) String() string {
+	return ""
+}
+
+func (anonymizedDocument) Masked() string {
+	return ""
+}
+
+func (anonymizedDocument) DocumentType() DocumentType {
+	return AnonymizedDocumentType
+}
+
+func (anonymizedDocument) PersonKind() PersonKind {
+	return UnknownPersonKind
+}
+
+// anonymizedPixKey is the primary pix key of an anonymized payee, it has no value
+type anonymizedPixKey struct{}
+
+func (anonymizedPixKey) Type() string {
+	return AnonymizedPixKeyType
+}
+
+func (anonymizedPixKey) Value() string {
+	return ""
+}
+
+func (anonymizedPixKey) String() string {
+	return ""
+}
+
+func (anonymizedPixKey) Masked() string {
+	return ""
+}
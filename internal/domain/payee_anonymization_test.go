@@ -0,0 +1,113 @@
+package domain_test
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"testing"
+
+	"github.com/italorfeitosa/payee-account-manager-api/internal/domain"
+	"github.com/italorfeitosa/payee-account-manager-api/test/fake"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPayee_Anonymize(t *testing.T) {
+	t.Run("given a valid payee should scrub personal data and keep id and status", func(t *testing.T) {
+		payee := fake.Payee().
+			WithTradeName("Feitosa Pagamentos").
+			WithDocument(fake.CNPJ()).
+			WithEmail("italo@feitosa.com").
+			WithStatus(domain.PayeeValidStatus).
+			MustBuild()
+		id, createdAt, bankAccount := payee.ID(), payee.CreatedAt(), *payee.BankAccount()
+
+		payee.Anonymize()
+
+		assert.True(t, payee.Anonymized())
+		assert.NotNil(t, payee.AnonymizedAt())
+		assert.Equal(t, id, payee.ID())
+		assert.Equal(t, domain.PayeeValidStatus, payee.Status())
+		assert.Equal(t, createdAt, payee.CreatedAt())
+		assert.Empty(t, payee.Name())
+		assert.Empty(t, payee.TradeName())
+		assert.Empty(t, payee.Email())
+		assert.Empty(t, payee.Document().Value())
+		assert.Equal(t, domain.AnonymizedDocumentType, payee.DocumentType())
+		assert.Nil(t, payee.Address())
+		assert.Nil(t, payee.InscricaoEstadual())
+		assert.Empty(t, payee.Contacts())
+		require.Len(t, payee.PixKeys(), 1)
+		assert.Equal(t, domain.AnonymizedPixKeyType, payee.PixKey().Type())
+		assert.Empty(t, payee.PixKey().Value())
+		assert.Empty(t, payee.BankAccount().AccountNumber)
+		assert.Empty(t, payee.BankAccount().AccountDigit)
+		assert.Equal(t, bankAccount.BankCode, payee.BankAccount().BankCode)
+	})
+
+	t.Run("given an anonymized payee should reject changes", func(t *testing.T) {
+		payee := fake.Payee().MustBuild()
+		payee.Anonymize()
+		pixKeyType, pixKey := fake.PixKey()
+
+		err := payee.EditDetails(fake.Name(), fake.CPF(), pixKeyType, pixKey, "")
+		assert.ErrorIs(t, err, domain.ErrPayeeAnonymized)
+
+		err = payee.AddPixKey(pixKeyType, pixKey)
+		assert.ErrorIs(t, err, domain.ErrPayeeAnonymized)
+
+		err = payee.Validate(*fake.BankAccount())
+		assert.ErrorIs(t, err, domain.ErrPayeeAnonymized)
+
+		assert.Empty(t, payee.Name())
+		assert.Equal(t, domain.PayeeDraftStatus, payee.Status())
+	})
+
+	t.Run("given an anonymized payee should reject changes to its scrubbed pix key", func(t *testing.T) {
+		payee := fake.Payee().MustBuild()
+		payee.Anonymize()
+		updatedAt := payee.UpdatedAt()
+
+		err := payee.SetPrimaryPixKey(domain.AnonymizedPixKeyType, "")
+		assert.ErrorIs(t, err, domain.ErrPayeeAnonymized)
+
+		err = payee.RemovePixKey(domain.AnonymizedPixKeyType, "")
+		assert.ErrorIs(t, err, domain.ErrPayeeAnonymized)
+
+		assert.Equal(t, updatedAt, payee.UpdatedAt())
+		assert.Equal(t, domain.AnonymizedPixKeyType, payee.PixKey().Type())
+	})
+
+	t.Run("given an anonymized payee restored should keep placeholders without warnings", func(t *testing.T) {
+		payee := fake.Payee().WithStatus(domain.PayeeValidStatus).MustBuild()
+		payee.Anonymize()
+
+		var logs bytes.Buffer
+		defaultLogger := slog.Default()
+		slog.SetDefault(slog.New(slog.NewTextHandler(&logs, nil)))
+		t.Cleanup(func() { slog.SetDefault(defaultLogger) })
+
+		restored := domain.RestorePayee(
+			context.Background(),
+			payee.ID(),
+			payee.Name(),
+			payee.Document().Value(),
+			payee.Status().Value(),
+			payee.Email(),
+			payee.PixKey().Type(),
+			payee.PixKey().Value(),
+			payee.BankAccount(),
+			domain.RestoreTimestamps(payee.CreatedAt(), payee.UpdatedAt()),
+			domain.RestoreAnonymizedAt(payee.AnonymizedAt()),
+		)
+
+		assert.Empty(t, logs.String())
+		assert.True(t, restored.Anonymized())
+		assert.Equal(t, payee.ID(), restored.ID())
+		assert.Equal(t, domain.PayeeValidStatus, restored.Status())
+		assert.Equal(t, domain.AnonymizedDocumentType, restored.DocumentType())
+		assert.Equal(t, domain.AnonymizedPixKeyType, restored.PixKey().Type())
+		assert.Empty(t, restored.Document().Masked())
+		assert.Empty(t, restored.PixKey().Masked())
+	})
+}
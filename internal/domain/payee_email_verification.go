@@ -0,0 +1,50 @@
+package domain
+
+import (
+	"errors"
+	"time"
+)
+
+var (
+	ErrEmailNotSet               = errors.New("payee has no email to verify")
+	ErrEmailVerificationMismatch = errors.New("email verified is not the current payee email")
+)
+
+// EmailVerified reports if current email of payee was confirmed by its owner
+func (p *PayeeEntity) EmailVerified() bool {
+	return p.emailVerifiedAt != nil
+}
+
+// EmailVerifiedAt returns when current email was confirmed, nil when it is not verified
+func (p *PayeeEntity) EmailVerifiedAt() *time.Time {
+	return p.emailVerifiedAt
+}
+
+// VerifyEmail marks email as verified, email is the address the verification was sent to,
+// so a confirmation of a replaced email is rejected
+func (p *PayeeEntity) VerifyEmail(email string) error {
+	if p.email == EmptyEmail {
+		return ErrEmailNotSet
+	}
+
+	if p.email.Value() != email {
+		return ErrEmailVerificationMismatch
+	}
+
+	if p.emailVerifiedAt != nil {
+		return nil
+	}
+
+	now := time.Now().UTC()
+	p.emailVerifiedAt = &now
+	p.updatedAt = now
+
+	return nil
+}
+
+// RestoreEmailVerifiedAt restores when payee email was verified, nil means not verified
+func RestoreEmailVerifiedAt(verifiedAt *time.Time) RestoreOption {
+	return func(p *PayeeEntity) {
+		p.emailVerifiedAt = verifiedAt
+	}
+}
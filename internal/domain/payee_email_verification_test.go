@@ -0,0 +1,51 @@
+package domain_test
+
+import (
+	"testing"
+
+	"github.com/italorfeitosa/payee-account-manager-api/internal/domain"
+	"github.com/italorfeitosa/payee-account-manager-api/test/fake"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPayee_VerifyEmail(t *testing.T) {
+	t.Run("given current email should mark it as verified", func(t *testing.T) {
+		payee := fake.Payee().WithEmail("italo@feitosa.com").MustBuild()
+
+		err := payee.VerifyEmail("italo@feitosa.com")
+
+		require.NoError(t, err)
+		assert.True(t, payee.EmailVerified())
+		assert.NotNil(t, payee.EmailVerifiedAt())
+	})
+
+	t.Run("given another email should return error", func(t *testing.T) {
+		payee := fake.Payee().WithEmail("italo@feitosa.com").MustBuild()
+
+		err := payee.VerifyEmail("other@feitosa.com")
+
+		assert.ErrorIs(t, err, domain.ErrEmailVerificationMismatch)
+		assert.False(t, payee.EmailVerified())
+	})
+
+	t.Run("given a payee without email should return error", func(t *testing.T) {
+		payee := fake.Payee().WithEmail("").MustBuild()
+
+		err := payee.VerifyEmail("")
+
+		assert.ErrorIs(t, err, domain.ErrEmailNotSet)
+	})
+
+	t.Run("given an email edited should reset verification", func(t *testing.T) {
+		payee := fake.Payee().WithEmail("italo@feitosa.com").MustBuild()
+		require.NoError(t, payee.VerifyEmail("italo@feitosa.com"))
+		pixKey := payee.PixKey()
+
+		require.NoError(t, payee.EditDetails(payee.Name(), payee.Document().Value(), pixKey.Type(), pixKey.Value(), "italo@feitosa.com"))
+		assert.True(t, payee.EmailVerified())
+
+		require.NoError(t, payee.EditDetails(payee.Name(), payee.Document().Value(), pixKey.Type(), pixKey.Value(), "other@feitosa.com"))
+		assert.False(t, payee.EmailVerified())
+	})
+}
@@ -1,18 +1,35 @@
 package domain
 
 import (
+	"context"
 	"errors"
 	"log/slog"
+	"slices"
+	"time"
 )
 
 type PayeeEntity struct {
-	id          EntityID
-	name        Name
-	document    Document
-	status      PayeeStatus
-	email       Email
-	pixKey      PixKey
+	id   EntityID
+	name Name
+	// tradeName is the nome fantasia of LEGAL payees
+	tradeName Name
+	// inscricaoEstadual is the optional state registration of LEGAL payees
+	inscricaoEstadual *InscricaoEstadual
+	document          Document
+	status            PayeeStatus
+	email             Email
+	// emailVerifiedAt is nil while email is not confirmed by its owner
+	emailVerifiedAt *time.Time
+	address         *Address
+	// contacts has at most one primary contact
+	contacts []Contact
+	// pixKeys holds the primary pix key at first position
+	pixKeys     []PixKey
 	bankAccount *BankAccount
+	createdAt   time.Time
+	updatedAt   time.Time
+	// anonymizedAt is nil while personal data of payee is kept
+	anonymizedAt *time.Time
 }
 
 func (p *PayeeEntity) ID() string {
@@ -23,65 +40,261 @@ func (p *PayeeEntity) Name() string {
 	return p.name.Value()
 }
 
+// NameSearchKey returns the normalized name to search payees
+func (p *PayeeEntity) NameSearchKey() string {
+	return p.name.SearchKey()
+}
+
+// TradeName returns the nome fantasia of payee, empty for NATURAL payees
+func (p *PayeeEntity) TradeName() string {
+	return p.tradeName.Value()
+}
+
+// InscricaoEstadual returns the state registration of payee, nil when not informed
+func (p *PayeeEntity) InscricaoEstadual() *InscricaoEstadual {
+	if p.inscricaoEstadual == nil {
+		return nil
+	}
+
+	ie := *p.inscricaoEstadual
+	return &ie
+}
+
+// TradeNameSearchKey returns the normalized trade name to search payees
+func (p *PayeeEntity) TradeNameSearchKey() string {
+	return p.tradeName.SearchKey()
+}
+
 func (p *PayeeEntity) Document() Document {
 	return p.document
 }
 
+func (p *PayeeEntity) DocumentType() DocumentType {
+	return p.document.DocumentType()
+}
+
+func (p *PayeeEntity) PersonKind() PersonKind {
+	return p.document.PersonKind()
+}
+
 func (p *PayeeEntity) Email() string {
 	return p.email.Value()
 }
 
+func (p *PayeeEntity) MaskedEmail() string {
+	return p.email.Masked()
+}
+
+// Address returns the postal address of payee, nil when not informed
+func (p *PayeeEntity) Address() *Address {
+	if p.address == nil {
+		return nil
+	}
+
+	address := *p.address
+	return &address
+}
+
+// Contacts returns the email and phone contacts of payee
+func (p *PayeeEntity) Contacts() []Contact {
+	return slices.Clone(p.contacts)
+}
+
 func (p *PayeeEntity) Status() PayeeStatus {
 	return p.status
 }
 
+// PixKey returns the primary pix key of payee
 func (p *PayeeEntity) PixKey() PixKey {
-	return p.pixKey
+	return p.pixKeys[0]
 }
 
 func (p *PayeeEntity) BankAccount() *BankAccount {
 	return p.bankAccount
 }
 
-// EditDetails updates payee information
-// when payee status is VALID, only email can be changed
+func (p *PayeeEntity) CreatedAt() time.Time {
+	return p.createdAt
+}
+
+func (p *PayeeEntity) UpdatedAt() time.Time {
+	return p.updatedAt
+}
+
+var (
+	// ErrTradeNameNotAllowed is returned when a trade name is given to a payee that is not a LEGAL person
+	ErrTradeNameNotAllowed = errors.New("trade name is allowed only for CNPJ payees")
+	// ErrInscricaoEstadualNotAllowed is returned when a state registration is given to a payee that is not a LEGAL person
+	ErrInscricaoEstadualNotAllowed = errors.New("inscricao estadual is allowed only for CNPJ payees")
+)
+
+// DetailsOption sets optional payee details in CreatePayee and EditDetails,
+// it is applied after name and document are set
+type DetailsOption func(*PayeeEntity) error
+
+// WithTradeName sets the nome fantasia of a LEGAL payee, an empty value clears it.
+// As name, it is ignored when payee status is not DRAFT
+func WithTradeName(tradeName string) DetailsOption {
+	return func(p *PayeeEntity) error {
+		if p.status != PayeeDraftStatus {
+			return nil
+		}
+
+		if tradeName == "" {
+			p.tradeName = EmptyName
+			return nil
+		}
+
+		if p.PersonKind() != LegalPersonKind {
+			return ErrTradeNameNotAllowed
+		}
+
+		var err error
+		p.tradeName, err = NewLegalName(tradeName)
+
+		return err
+	}
+}
+
+// WithInscricaoEstadual sets the state registration of a LEGAL payee, nil clears it.
+// As name, it is ignored when payee status is not DRAFT
+func WithInscricaoEstadual(ie *InscricaoEstadual) DetailsOption {
+	return func(p *PayeeEntity) error {
+		if p.status != PayeeDraftStatus {
+			return nil
+		}
+
+		if ie != nil && p.PersonKind() != LegalPersonKind {
+			return ErrInscricaoEstadualNotAllowed
+		}
+
+		p.inscricaoEstadual = ie
+		return nil
+	}
+}
+
+// WithAddress sets the postal address of payee, nil clears it. As email, it is editable in any status
+func WithAddress(address *Address) DetailsOption {
+	return func(p *PayeeEntity) error {
+		p.address = address
+		return nil
+	}
+}
+
+// WithContacts replaces the contacts of payee, exactly one must be primary when not empty.
+// As email, they are editable in any status
+func WithContacts(contacts []Contact) DetailsOption {
+	return func(p *PayeeEntity) error {
+		if err := validateContacts(contacts); err != nil {
+			return err
+		}
+
+		p.contacts = slices.Clone(contacts)
+		return nil
+	}
+}
+
+var (
+	ErrPayeeNotDraft         = errors.New("payee can be validated only when status is DRAFT")
+	ErrIncompleteBankAccount = errors.New("bank account must have account number, branch number and bank code")
+)
+
+// CanValidate returns the error Validate would return, so checks that depend on external
+// services run only when payee can be validated
+func (p *PayeeEntity) CanValidate(bankAccount BankAccount) error {
+	if p.Anonymized() {
+		return ErrPayeeAnonymized
+	}
+
+	if p.status != PayeeDraftStatus {
+		return ErrPayeeNotDraft
+	}
+
+	if bankAccount.AccountNumber == "" || bankAccount.BranchNumber == "" || bankAccount.BankCode == "" {
+		return ErrIncompleteBankAccount
+	}
+
+	return nil
+}
+
+// Validate turns a DRAFT payee into VALID, attaching the bank account confirmed for its pix key
+func (p *PayeeEntity) Validate(bankAccount BankAccount) error {
+	if err := p.CanValidate(bankAccount); err != nil {
+		return err
+	}
+
+	p.status = PayeeValidStatus
+	p.bankAccount = &bankAccount
+	p.updatedAt = time.Now().UTC()
+
+	return nil
+}
+
+// EditDetails updates payee information, the pix key replaces the primary pix key
+// when payee status is VALID, only email, address and contacts can be changed
 func (p *PayeeEntity) EditDetails(
 	name string,
 	document string,
 	pixKeyType string,
 	pixKey string,
 	email string,
+	opts ...DetailsOption,
 ) error {
+	if p.Anonymized() {
+		return ErrPayeeAnonymized
+	}
+
+	// changes are made on a copy, so payee is kept unchanged when any value is invalid
+	edited := *p
+
 	var err error
 
+	edited.email = EmptyEmail
 	if email != "" {
-		p.email, err = NewEmail(email)
+		edited.email, err = NewEmail(email)
 		if err != nil {
 			return err
 		}
-	} else {
-		p.email = EmptyEmail
 	}
 
-	if p.status != PayeeDraftStatus {
-		return nil
+	if edited.email != p.email {
+		edited.emailVerifiedAt = nil
 	}
 
-	p.name, err = NewName(name)
-	if err != nil {
-		return err
-	}
+	if edited.status == PayeeDraftStatus {
+		edited.document, err = NewDocument(document)
+		if err != nil {
+			return err
+		}
 
-	p.document, err = NewDocument(document)
-	if err != nil {
-		return err
+		edited.name, err = NewNameFor(name, edited.PersonKind())
+		if err != nil {
+			return err
+		}
+
+		// trade name and inscricao estadual of a previous CNPJ are dropped, opts can set them again
+		if edited.PersonKind() != LegalPersonKind {
+			edited.tradeName = EmptyName
+			edited.inscricaoEstadual = nil
+		}
+
+		primaryPixKey, err := NewPixKey(pixKeyType, pixKey)
+		if err != nil {
+			return err
+		}
+
+		edited.replacePrimaryPixKey(primaryPixKey)
 	}
 
-	p.pixKey, err = NewPixKey(pixKeyType, pixKey)
-	if err != nil {
-		return err
+	for _, opt := range opts {
+		if err := opt(&edited); err != nil {
+			return err
+		}
 	}
 
+	edited.updatedAt = time.Now().UTC()
+	*p = edited
+
 	return nil
 }
 
@@ -92,28 +305,38 @@ func CreatePayee(
 	pixKeyType string,
 	pixKey string,
 	email string,
+	opts ...DetailsOption,
 ) (*PayeeEntity, error) {
 	var err error
 
 	payee := new(PayeeEntity)
 
 	payee.id = NewEntityID()
+	payee.status = PayeeDraftStatus
 
-	payee.name, err = NewName(name)
+	payee.document, err = NewDocument(document)
 	if err != nil {
 		return nil, err
 	}
 
-	payee.document, err = NewDocument(document)
+	payee.name, err = NewNameFor(name, payee.PersonKind())
 	if err != nil {
 		return nil, err
 	}
 
-	payee.pixKey, err = NewPixKey(pixKeyType, pixKey)
+	for _, opt := range opts {
+		if err := opt(payee); err != nil {
+			return nil, err
+		}
+	}
+
+	primaryPixKey, err := NewPixKey(pixKeyType, pixKey)
 	if err != nil {
 		return nil, err
 	}
 
+	payee.pixKeys = []PixKey{primaryPixKey}
+
 	if email != "" {
 		payee.email, err = NewEmail(email)
 		if err != nil {
@@ -121,13 +344,71 @@ func CreatePayee(
 		}
 	}
 
-	payee.status = PayeeDraftStatus
+	payee.createdAt = time.Now().UTC()
+	payee.updatedAt = payee.createdAt
 
 	return payee, nil
 }
 
-// RestorePayee is a factory function to restore a PayeeEntity from database
+// RestoreOption restores optional PayeeEntity attributes in RestorePayee
+type RestoreOption func(*PayeeEntity)
+
+// RestoreTimestamps restores creation and last update times of payee
+func RestoreTimestamps(createdAt, updatedAt time.Time) RestoreOption {
+	return func(p *PayeeEntity) {
+		p.createdAt = createdAt
+		p.updatedAt = updatedAt
+	}
+}
+
+// RestoreTradeName restores the nome fantasia of payee
+func RestoreTradeName(tradeName string) RestoreOption {
+	return func(p *PayeeEntity) {
+		p.tradeName = Name{tradeName}
+	}
+}
+
+// RestoreAddress restores the postal address of payee, it is validated by RestorePayee
+func RestoreAddress(street, number, complement, neighborhood, city, uf, cep string) RestoreOption {
+	return func(p *PayeeEntity) {
+		p.address = &Address{
+			street:       street,
+			number:       number,
+			complement:   complement,
+			neighborhood: neighborhood,
+			city:         city,
+			uf:           UF(uf),
+			cep:          CEP{cep},
+		}
+	}
+}
+
+// RestoreInscricaoEstadual restores the state registration of payee, it is validated by RestorePayee
+func RestoreInscricaoEstadual(uf, value string) RestoreOption {
+	return func(p *PayeeEntity) {
+		p.inscricaoEstadual = &InscricaoEstadual{UF(uf), value}
+	}
+}
+
+// RestoreContact appends a contact of payee, contacts are validated by RestorePayee
+func RestoreContact(typ, value string, primary bool) RestoreOption {
+	return func(p *PayeeEntity) {
+		p.contacts = append(p.contacts, Contact{ContactType(typ), value, primary})
+	}
+}
+
+// RestoreAdditionalPixKey restores a non primary pix key of payee, it is validated by RestorePayee
+func RestoreAdditionalPixKey(pixKeyType, pixKeyValue string) RestoreOption {
+	return func(p *PayeeEntity) {
+		p.pixKeys = append(p.pixKeys, restoredPixKey{pixKeyType, pixKeyValue})
+	}
+}
+
+// RestorePayee is a factory function to restore a PayeeEntity from database,
+// pixKeyType and pixKeyValue are the primary pix key. Tempered values are kept,
+// so they won't break api, and logged with ctx, so warnings carry request attributes
 func RestorePayee(
+	ctx context.Context,
 	id string,
 	name string,
 	document string,
@@ -136,36 +417,64 @@ func RestorePayee(
 	pixKeyType string,
 	pixKeyValue string,
 	bankAccount *BankAccount,
+	opts ...RestoreOption,
 ) *PayeeEntity {
 
 	payeeStatus, err := restorePayeeStatus(status)
 	if errors.Is(err, ErrTemperedValue) {
-		slog.Warn("tempered payee status", slog.String("payee_id", id))
+		slog.WarnContext(ctx, "tempered payee status", slog.String("payee_id", id))
 
 		payeeStatus = PayeeStatus{status, status}
 	}
 
-	pixKey, err := NewPixKey(pixKeyType, pixKeyValue)
-	if err != nil {
-		slog.Warn("tempered pix key with invalid values", slog.String("payee_id", id), slog.String("error", err.Error()))
+	payee := &PayeeEntity{
+		id:          EntityID{id},
+		name:        Name{name},
+		status:      payeeStatus,
+		email:       Email{email},
+		bankAccount: bankAccount,
+	}
+
+	for _, opt := range opts {
+		opt(payee)
+	}
+
+	// scrubbed values are not validated, as they would be reported as tempered
+	if payee.Anonymized() {
+		payee.document = anonymizedDocument{}
+		payee.pixKeys = []PixKey{anonymizedPixKey{}}
 
-		pixKey = restoredPixKey{pixKeyType, pixKeyValue}
+		return payee
 	}
 
 	payeeDocument, err := NewDocument(document)
 	if err != nil {
-		slog.Warn("tempered document with invalid values", slog.String("payee_id", id), slog.String("error", err.Error()))
+		slog.WarnContext(ctx, "tempered document with invalid values", slog.String("payee_id", id), slog.String("error", err.Error()))
 
 		payeeDocument = restoredDocument{document}
 	}
 
-	return &PayeeEntity{
-		id:          EntityID{id},
-		name:        Name{name},
-		document:    payeeDocument,
-		status:      payeeStatus,
-		email:       Email{email},
-		pixKey:      pixKey,
-		bankAccount: bankAccount,
+	payee.document = payeeDocument
+
+	// additional pix keys were appended by opts, primary pix key goes first
+	pixKeys := slices.Insert(payee.pixKeys, 0, PixKey(restoredPixKey{pixKeyType, pixKeyValue}))
+	for i, key := range pixKeys {
+		pixKeys[i] = restorePixKey(ctx, key.Type(), key.Value(), slog.String("payee_id", id))
+	}
+
+	payee.pixKeys = pixKeys
+
+	if payee.address != nil {
+		address := restoreAddress(ctx, *payee.address, slog.String("payee_id", id))
+		payee.address = &address
+	}
+
+	if payee.inscricaoEstadual != nil {
+		ie := restoreInscricaoEstadual(ctx, *payee.inscricaoEstadual, slog.String("payee_id", id))
+		payee.inscricaoEstadual = &ie
 	}
+
+	payee.contacts = restoreContacts(ctx, payee.contacts, slog.String("payee_id", id))
+
+	return payee
 }
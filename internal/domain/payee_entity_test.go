@@ -1,6 +1,9 @@
 package domain_test
 
 import (
+	"bytes"
+	"context"
+	"log/slog"
 	"testing"
 
 	"github.com/brianvoe/gofakeit/v7"
@@ -186,6 +189,7 @@ func TestPayee_EditDetails(t *testing.T) {
 			wantPixKeyType, wantPixKey := fake.PixKey()
 
 			payee := domain.RestorePayee(
+				context.Background(),
 				wantID.Value(),
 				wantName,
 				wantDocument,
@@ -219,6 +223,240 @@ func TestPayee_EditDetails(t *testing.T) {
 		})
 	}
 
+	t.Run("given an invalid value should keep payee unchanged", func(t *testing.T) {
+		address, err := domain.NewAddress("Avenida Paulista", "1578", "", "Bela Vista", "São Paulo", "SP", "01310-200")
+		require.NoError(t, err)
+		contact, err := domain.NewContact("EMAIL", "italo@feitosa.com", false)
+		require.NoError(t, err)
+
+		tests := []struct {
+			name     string
+			document string
+			pixKey   string
+			opts     []domain.DetailsOption
+			wantErr  error
+		}{
+			{name: "document", document: "123", pixKey: "99818083008", wantErr: domain.ErrInvalidDocument},
+			{name: "pix key", document: "99818083008", pixKey: "123", wantErr: domain.ErrInvalidCPF},
+			{
+				name: "contacts", document: "99818083008", pixKey: "99818083008",
+				opts:    []domain.DetailsOption{domain.WithAddress(&address), domain.WithContacts([]domain.Contact{contact})},
+				wantErr: domain.ErrPrimaryContactRequired,
+			},
+		}
+		for _, tt := range tests {
+			t.Run("given an invalid "+tt.name, func(t *testing.T) {
+				payee, err := domain.CreatePayee("Italo Feitosa", "19039318000104", domain.CNPJPixKeyType, "19039318000104", "italo@feitosa.com")
+				require.NoError(t, err)
+				want := *payee
+
+				err = payee.EditDetails("Italo Feitosa", tt.document, domain.CPFPixKeyType, tt.pixKey, "other@feitosa.com", tt.opts...)
+
+				assert.ErrorIs(t, err, tt.wantErr)
+				assert.Equal(t, want, *payee)
+			})
+		}
+	})
+}
+
+func TestPayee_TradeName(t *testing.T) {
+	t.Run("given a CNPJ payee should keep legal and trade names", func(t *testing.T) {
+		pixKeyType, pixKey := fake.PixKey()
+
+		payee, err := domain.CreatePayee("Nu Pagamentos s.a", fake.CNPJ(), pixKeyType, pixKey, "", domain.WithTradeName("Nubank"))
+
+		require.NoError(t, err)
+		assert.Equal(t, "Nu Pagamentos S.A.", payee.Name())
+		assert.Equal(t, "Nubank", payee.TradeName())
+	})
+
+	t.Run("given a CPF payee should reject trade name", func(t *testing.T) {
+		pixKeyType, pixKey := fake.PixKey()
+
+		payee, err := domain.CreatePayee("Italo Feitosa", fake.CPF(), pixKeyType, pixKey, "", domain.WithTradeName("Italo"))
+
+		assert.Nil(t, payee)
+		assert.ErrorIs(t, err, domain.ErrTradeNameNotAllowed)
+	})
+
+	t.Run("given a CPF payee should reject single word name", func(t *testing.T) {
+		pixKeyType, pixKey := fake.PixKey()
+
+		payee, err := domain.CreatePayee("Nubank", fake.CPF(), pixKeyType, pixKey, "")
+
+		assert.Nil(t, payee)
+		assert.ErrorIs(t, err, domain.ErrNameLessThenTwoWords)
+	})
+
+	t.Run("given a CNPJ payee edited to CPF should drop trade name", func(t *testing.T) {
+		pixKeyType, pixKey := fake.PixKey()
+		payee, err := domain.CreatePayee("Nu Pagamentos", fake.CNPJ(), pixKeyType, pixKey, "", domain.WithTradeName("Nubank"))
+		require.NoError(t, err)
+
+		err = payee.EditDetails("Italo Feitosa", fake.CPF(), pixKeyType, pixKey, "")
+
+		require.NoError(t, err)
+		assert.Empty(t, payee.TradeName())
+	})
+}
+
+func TestPayee_InscricaoEstadual(t *testing.T) {
+	ie, err := domain.NewInscricaoEstadual("SP", "110.042.490.114")
+	require.NoError(t, err)
+
+	t.Run("given a CNPJ payee should keep inscricao estadual", func(t *testing.T) {
+		pixKeyType, pixKey := fake.PixKey()
+
+		payee, err := domain.CreatePayee("Fake Company", fake.CNPJ(), pixKeyType, pixKey, "", domain.WithInscricaoEstadual(&ie))
+
+		require.NoError(t, err)
+		assert.Equal(t, &ie, payee.InscricaoEstadual())
+	})
+
+	t.Run("given a CPF payee should reject inscricao estadual", func(t *testing.T) {
+		pixKeyType, pixKey := fake.PixKey()
+
+		payee, err := domain.CreatePayee("Italo Feitosa", fake.CPF(), pixKeyType, pixKey, "", domain.WithInscricaoEstadual(&ie))
+
+		assert.Nil(t, payee)
+		assert.ErrorIs(t, err, domain.ErrInscricaoEstadualNotAllowed)
+	})
+
+	t.Run("given a VALID payee should not change inscricao estadual", func(t *testing.T) {
+		payee := fake.Payee().WithDocument(fake.CNPJ()).WithStatus(domain.PayeeValidStatus).MustBuild()
+		pixKey := payee.PixKey()
+
+		err := payee.EditDetails(payee.Name(), payee.Document().Value(), pixKey.Type(), pixKey.Value(), payee.Email(),
+			domain.WithInscricaoEstadual(&ie),
+		)
+
+		require.NoError(t, err)
+		assert.Nil(t, payee.InscricaoEstadual())
+	})
+}
+
+func TestPayee_AddressAndContacts(t *testing.T) {
+	address, err := domain.NewAddress("Avenida Paulista", "1578", "", "Bela Vista", "São Paulo", "SP", "01310-200")
+	require.NoError(t, err)
+
+	email, err := domain.NewContact("EMAIL", "italo@feitosa.com", true)
+	require.NoError(t, err)
+	phone, err := domain.NewContact("PHONE", "(11) 3123-4567", false)
+	require.NoError(t, err)
+
+	t.Run("given a VALID payee should edit address and contacts", func(t *testing.T) {
+		payee := fake.Payee().WithStatus(domain.PayeeValidStatus).MustBuild()
+		pixKey := payee.PixKey()
+
+		err := payee.EditDetails(payee.Name(), payee.Document().Value(), pixKey.Type(), pixKey.Value(), payee.Email(),
+			domain.WithAddress(&address),
+			domain.WithContacts([]domain.Contact{email, phone}),
+		)
+
+		require.NoError(t, err)
+		assert.Equal(t, &address, payee.Address())
+		assert.Equal(t, []domain.Contact{email, phone}, payee.Contacts())
+	})
+
+	t.Run("given contacts without primary should return error", func(t *testing.T) {
+		payee := createRandomPayee()
+		pixKey := payee.PixKey()
+
+		err := payee.EditDetails(payee.Name(), payee.Document().Value(), pixKey.Type(), pixKey.Value(), payee.Email(),
+			domain.WithContacts([]domain.Contact{phone}),
+		)
+
+		assert.ErrorIs(t, err, domain.ErrPrimaryContactRequired)
+	})
+
+	t.Run("given duplicated contacts should return error", func(t *testing.T) {
+		payee := createRandomPayee()
+		pixKey := payee.PixKey()
+
+		err := payee.EditDetails(payee.Name(), payee.Document().Value(), pixKey.Type(), pixKey.Value(), payee.Email(),
+			domain.WithContacts([]domain.Contact{email, phone, phone}),
+		)
+
+		assert.ErrorIs(t, err, domain.ErrDuplicatedContact)
+	})
+}
+
+func TestRestorePayee_TemperedDetails(t *testing.T) {
+	validAddress := domain.RestoreAddress("Avenida Paulista", "1578", "", "Bela Vista", "São Paulo", "SP", "01310200")
+	validContact := domain.RestoreContact("EMAIL", "italo@feitosa.com", true)
+
+	testCases := []struct {
+		name    string
+		opts    []domain.RestoreOption
+		wantMsg string
+	}{
+		{
+			name:    "given valid details should not warn",
+			opts:    []domain.RestoreOption{validAddress, domain.RestoreInscricaoEstadual("SP", "110042490114"), validContact},
+			wantMsg: "",
+		},
+		{
+			name:    "given a cep of other uf should warn tempered address",
+			opts:    []domain.RestoreOption{domain.RestoreAddress("Avenida Paulista", "1578", "", "Bela Vista", "São Paulo", "RJ", "01310200")},
+			wantMsg: "tempered address with invalid values",
+		},
+		{
+			name:    "given an unknown uf should warn tempered inscricao estadual",
+			opts:    []domain.RestoreOption{domain.RestoreInscricaoEstadual("XX", "110042490114")},
+			wantMsg: "tempered inscricao estadual with invalid values",
+		},
+		{
+			name:    "given an invalid phone should warn tempered contact",
+			opts:    []domain.RestoreOption{validContact, domain.RestoreContact("PHONE", "5500912345678", false)},
+			wantMsg: "tempered contact with invalid values",
+		},
+		{
+			name:    "given contacts without primary should warn tempered contacts",
+			opts:    []domain.RestoreOption{domain.RestoreContact("EMAIL", "italo@feitosa.com", false)},
+			wantMsg: "tempered contacts with invalid values",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			defaultLogger := slog.Default()
+			slog.SetDefault(slog.New(slog.NewJSONHandler(&buf, nil)))
+			t.Cleanup(func() { slog.SetDefault(defaultLogger) })
+
+			payee := domain.RestorePayee(
+				context.Background(),
+				domain.NewEntityID().Value(), "Italo Feitosa LTDA", "19039318000104", domain.PayeeDraftStatus.Value(),
+				"", domain.CNPJPixKeyType, "19039318000104", nil,
+				tc.opts...,
+			)
+
+			if tc.wantMsg == "" {
+				assert.Empty(t, buf.String())
+				return
+			}
+
+			assert.Contains(t, buf.String(), `"level":"WARN"`)
+			assert.Contains(t, buf.String(), tc.wantMsg)
+			assert.Contains(t, buf.String(), payee.ID())
+		})
+	}
+
+	t.Run("given tempered details should keep restored values", func(t *testing.T) {
+		payee := domain.RestorePayee(
+			context.Background(),
+			domain.NewEntityID().Value(), "Italo Feitosa LTDA", "19039318000104", domain.PayeeDraftStatus.Value(),
+			"", domain.CNPJPixKeyType, "19039318000104", nil,
+			domain.RestoreAddress("Avenida Paulista", "1578", "", "Bela Vista", "São Paulo", "RJ", "01310200"),
+			domain.RestoreInscricaoEstadual("XX", "110042490114"),
+			domain.RestoreContact("PHONE", "5500912345678", true),
+		)
+
+		assert.Equal(t, "01310200", payee.Address().CEP().Value())
+		assert.Equal(t, domain.UF("RJ"), payee.Address().UF())
+		assert.Equal(t, domain.UF("XX"), payee.InscricaoEstadual().UF())
+		assert.Equal(t, "5500912345678", payee.Contacts()[0].Value())
+	})
 }
 
 func createRandomPayee() *domain.PayeeEntity {
@@ -237,3 +475,48 @@ func createRandomPayee() *domain.PayeeEntity {
 
 	return payee
 }
+
+func TestPayee_Validate(t *testing.T) {
+	bankAccount := domain.BankAccount{
+		AccountType:   "CONTA_CORRENTE",
+		AccountNumber: "65465465",
+		AccountDigit:  "5",
+		BranchNumber:  "0001",
+		BankCode:      "1",
+		BankIspb:      "54545",
+	}
+
+	t.Run("given a draft payee should turn it valid with bank account", func(t *testing.T) {
+		payee := createRandomPayee()
+
+		err := payee.Validate(bankAccount)
+
+		require.NoError(t, err)
+		assert.Equal(t, domain.PayeeValidStatus, payee.Status())
+		assert.Equal(t, &bankAccount, payee.BankAccount())
+	})
+
+	t.Run("given a valid payee should return error", func(t *testing.T) {
+		payee := fake.Payee().WithStatus(domain.PayeeValidStatus).MustBuild()
+
+		assert.ErrorIs(t, payee.Validate(bankAccount), domain.ErrPayeeNotDraft)
+	})
+
+	t.Run("given an incomplete bank account should return error", func(t *testing.T) {
+		payee := createRandomPayee()
+
+		err := payee.Validate(domain.BankAccount{AccountNumber: "65465465"})
+
+		assert.ErrorIs(t, err, domain.ErrIncompleteBankAccount)
+		assert.Equal(t, domain.PayeeDraftStatus, payee.Status())
+	})
+	t.Run("given a payee should tell if it can be validated without changing it", func(t *testing.T) {
+		draft := createRandomPayee()
+		valid := fake.Payee().WithStatus(domain.PayeeValidStatus).MustBuild()
+
+		assert.NoError(t, draft.CanValidate(bankAccount))
+		assert.Equal(t, domain.PayeeDraftStatus, draft.Status())
+		assert.ErrorIs(t, valid.CanValidate(bankAccount), domain.ErrPayeeNotDraft)
+		assert.ErrorIs(t, draft.CanValidate(domain.BankAccount{}), domain.ErrIncompleteBankAccount)
+	})
+}
@@ -0,0 +1,126 @@
+package domain
+
+import (
+	"errors"
+	"slices"
+	"time"
+)
+
+var (
+	ErrPixKeysNotEditable   = errors.New("pix keys can be changed only when payee status is DRAFT")
+	ErrDuplicatedPixKey     = errors.New("pix key already belongs to payee")
+	ErrPixKeyNotFound       = errors.New("pix key does not belong to payee")
+	ErrPrimaryPixKeyRemoval = errors.New("primary pix key cannot be removed, set another primary pix key first")
+)
+
+// PixKeys returns all pix keys of payee, the first one is the primary pix key
+func (p *PayeeEntity) PixKeys() []PixKey {
+	return slices.Clone(p.pixKeys)
+}
+
+// AddPixKey appends a non primary pix key to payee, only when status is DRAFT
+func (p *PayeeEntity) AddPixKey(pixKeyType, pixKey string) error {
+	if p.Anonymized() {
+		return ErrPayeeAnonymized
+	}
+
+	if p.status != PayeeDraftStatus {
+		return ErrPixKeysNotEditable
+	}
+
+	key, err := NewPixKey(pixKeyType, pixKey)
+	if err != nil {
+		return err
+	}
+
+	if p.indexOfPixKey(key) >= 0 {
+		return ErrDuplicatedPixKey
+	}
+
+	p.pixKeys = append(p.pixKeys, key)
+	p.updatedAt = time.Now().UTC()
+
+	return nil
+}
+
+// RemovePixKey removes a non primary pix key from payee, only when status is DRAFT
+func (p *PayeeEntity) RemovePixKey(pixKeyType, pixKey string) error {
+	if p.Anonymized() {
+		return ErrPayeeAnonymized
+	}
+
+	if p.status != PayeeDraftStatus {
+		return ErrPixKeysNotEditable
+	}
+
+	i := p.indexOfPixKey(parsePixKeyForLookup(pixKeyType, pixKey))
+
+	switch {
+	case i < 0:
+		return ErrPixKeyNotFound
+	case i == 0:
+		return ErrPrimaryPixKeyRemoval
+	}
+
+	p.pixKeys = slices.Delete(p.pixKeys, i, i+1)
+	p.updatedAt = time.Now().UTC()
+
+	return nil
+}
+
+// SetPrimaryPixKey turns one of payee pix keys into the primary, only when status is DRAFT
+func (p *PayeeEntity) SetPrimaryPixKey(pixKeyType, pixKey string) error {
+	if p.Anonymized() {
+		return ErrPayeeAnonymized
+	}
+
+	if p.status != PayeeDraftStatus {
+		return ErrPixKeysNotEditable
+	}
+
+	i := p.indexOfPixKey(parsePixKeyForLookup(pixKeyType, pixKey))
+	if i < 0 {
+		return ErrPixKeyNotFound
+	}
+
+	primary := p.pixKeys[i]
+	p.pixKeys = slices.Insert(slices.Delete(p.pixKeys, i, i+1), 0, primary)
+	p.updatedAt = time.Now().UTC()
+
+	return nil
+}
+
+// replacePrimaryPixKey sets key as primary, dropping the previous primary
+// and the non primary pix key equal to key, if any
+func (p *PayeeEntity) replacePrimaryPixKey(key PixKey) {
+	pixKeys := []PixKey{key}
+
+	for _, current := range p.pixKeys[min(1, len(p.pixKeys)):] {
+		if !samePixKey(current, key) {
+			pixKeys = append(pixKeys, current)
+		}
+	}
+
+	p.pixKeys = pixKeys
+}
+
+func (p *PayeeEntity) indexOfPixKey(key PixKey) int {
+	return slices.IndexFunc(p.pixKeys, func(current PixKey) bool {
+		return samePixKey(current, key)
+	})
+}
+
+func samePixKey(a, b PixKey) bool {
+	return a.Type() == b.Type() && a.Value() == b.Value()
+}
+
+// parsePixKeyForLookup normalizes the pix key value to compare with payee pix keys,
+// falling back to raw value to find tempered pix keys
+func parsePixKeyForLookup(pixKeyType, pixKey string) PixKey {
+	key, err := NewPixKey(pixKeyType, pixKey)
+	if err != nil {
+		return restoredPixKey{pixKeyType, pixKey}
+	}
+
+	return key
+}
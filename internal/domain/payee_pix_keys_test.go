@@ -0,0 +1,190 @@
+package domain_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/italorfeitosa/payee-account-manager-api/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func createPayeeWithPixKeys(t *testing.T) *domain.PayeeEntity {
+	t.Helper()
+
+	payee, err := domain.CreatePayee("Italo Feitosa", "99818083008", domain.CPFPixKeyType, "99818083008", "")
+	require.NoError(t, err)
+
+	require.NoError(t, payee.AddPixKey(domain.EmailPixKeyType, "italo@feitosa.com"))
+	require.NoError(t, payee.AddPixKey(domain.TelefonePixKeyType, "+5511912345678"))
+
+	return payee
+}
+
+func pixKeyValues(payee *domain.PayeeEntity) []string {
+	var values []string
+	for _, key := range payee.PixKeys() {
+		values = append(values, key.Value())
+	}
+	return values
+}
+
+func TestPayee_AddPixKey(t *testing.T) {
+	t.Run("given a draft payee should append non primary pix keys", func(t *testing.T) {
+		payee := createPayeeWithPixKeys(t)
+
+		assert.Equal(t, []string{"99818083008", "italo@feitosa.com", "5511912345678"}, pixKeyValues(payee))
+		assert.Equal(t, "99818083008", payee.PixKey().Value())
+	})
+
+	t.Run("given a duplicated pix key should return error", func(t *testing.T) {
+		payee := createPayeeWithPixKeys(t)
+
+		err := payee.AddPixKey(domain.TelefonePixKeyType, "(11) 91234-5678")
+
+		assert.ErrorIs(t, err, domain.ErrDuplicatedPixKey)
+	})
+
+	t.Run("given an invalid pix key should return error", func(t *testing.T) {
+		payee := createPayeeWithPixKeys(t)
+
+		err := payee.AddPixKey(domain.CPFPixKeyType, "123")
+
+		assert.ErrorIs(t, err, domain.ErrInvalidCPF)
+	})
+
+	t.Run("given a valid payee should return error", func(t *testing.T) {
+		payee := domain.RestorePayee(
+			context.Background(),
+			domain.NewEntityID().Value(), "Italo Feitosa", "99818083008", domain.PayeeValidStatus.Value(),
+			"", domain.CPFPixKeyType, "99818083008", nil,
+		)
+
+		err := payee.AddPixKey(domain.EmailPixKeyType, "italo@feitosa.com")
+
+		assert.ErrorIs(t, err, domain.ErrPixKeysNotEditable)
+	})
+}
+
+func TestPayee_RemovePixKey(t *testing.T) {
+	t.Run("given a non primary pix key should remove it", func(t *testing.T) {
+		payee := createPayeeWithPixKeys(t)
+
+		err := payee.RemovePixKey(domain.EmailPixKeyType, "italo@feitosa.com")
+
+		require.NoError(t, err)
+		assert.Equal(t, []string{"99818083008", "5511912345678"}, pixKeyValues(payee))
+	})
+
+	t.Run("given the primary pix key should return error", func(t *testing.T) {
+		payee := createPayeeWithPixKeys(t)
+
+		err := payee.RemovePixKey(domain.CPFPixKeyType, "998.180.830-08")
+
+		assert.ErrorIs(t, err, domain.ErrPrimaryPixKeyRemoval)
+	})
+
+	t.Run("given an unknown pix key should return error", func(t *testing.T) {
+		payee := createPayeeWithPixKeys(t)
+
+		err := payee.RemovePixKey(domain.EmailPixKeyType, "other@feitosa.com")
+
+		assert.ErrorIs(t, err, domain.ErrPixKeyNotFound)
+	})
+}
+
+func TestPayee_SetPrimaryPixKey(t *testing.T) {
+	t.Run("given a non primary pix key should move it to primary", func(t *testing.T) {
+		payee := createPayeeWithPixKeys(t)
+
+		err := payee.SetPrimaryPixKey(domain.TelefonePixKeyType, "+5511912345678")
+
+		require.NoError(t, err)
+		assert.Equal(t, []string{"5511912345678", "99818083008", "italo@feitosa.com"}, pixKeyValues(payee))
+		assert.Equal(t, domain.TelefonePixKeyType, payee.PixKey().Type())
+	})
+
+	t.Run("given an unknown pix key should return error", func(t *testing.T) {
+		payee := createPayeeWithPixKeys(t)
+
+		err := payee.SetPrimaryPixKey(domain.EmailPixKeyType, "other@feitosa.com")
+
+		assert.ErrorIs(t, err, domain.ErrPixKeyNotFound)
+	})
+}
+
+func TestPayee_PixKeys_UpdatedAt(t *testing.T) {
+	testCases := []struct {
+		name   string
+		change func(payee *domain.PayeeEntity) error
+	}{
+		{
+			name: "given an added pix key should update updated at",
+			change: func(payee *domain.PayeeEntity) error {
+				return payee.AddPixKey(domain.TelefonePixKeyType, "+5511912345678")
+			},
+		},
+		{
+			name: "given a removed pix key should update updated at",
+			change: func(payee *domain.PayeeEntity) error {
+				return payee.RemovePixKey(domain.EmailPixKeyType, "italo@feitosa.com")
+			},
+		},
+		{
+			name: "given a primary pix key set should update updated at",
+			change: func(payee *domain.PayeeEntity) error {
+				return payee.SetPrimaryPixKey(domain.EmailPixKeyType, "italo@feitosa.com")
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			createdAt := time.Now().UTC().Add(-time.Hour)
+			payee := domain.RestorePayee(
+				context.Background(),
+				domain.NewEntityID().Value(), "Italo Feitosa", "99818083008", domain.PayeeDraftStatus.Value(),
+				"", domain.CPFPixKeyType, "99818083008", nil,
+				domain.RestoreAdditionalPixKey(domain.EmailPixKeyType, "italo@feitosa.com"),
+				domain.RestoreTimestamps(createdAt, createdAt),
+			)
+
+			require.NoError(t, tc.change(payee))
+
+			assert.True(t, payee.UpdatedAt().After(createdAt))
+			assert.Equal(t, createdAt, payee.CreatedAt())
+		})
+	}
+}
+
+func TestPayee_EditDetails_PixKeys(t *testing.T) {
+	t.Run("given a pix key should replace only primary pix key", func(t *testing.T) {
+		payee := createPayeeWithPixKeys(t)
+
+		err := payee.EditDetails("Italo Feitosa", "99818083008", domain.CNPJPixKeyType, "19039318000104", "")
+
+		require.NoError(t, err)
+		assert.Equal(t, []string{"19039318000104", "italo@feitosa.com", "5511912345678"}, pixKeyValues(payee))
+	})
+
+	t.Run("given a non primary pix key should turn it primary without duplicating", func(t *testing.T) {
+		payee := createPayeeWithPixKeys(t)
+
+		err := payee.EditDetails("Italo Feitosa", "99818083008", domain.EmailPixKeyType, "italo@feitosa.com", "")
+
+		require.NoError(t, err)
+		assert.Equal(t, []string{"italo@feitosa.com", "5511912345678"}, pixKeyValues(payee))
+	})
+}
+
+func TestRestorePayee_PixKeys(t *testing.T) {
+	payee := domain.RestorePayee(
+		context.Background(),
+		domain.NewEntityID().Value(), "Italo Feitosa", "99818083008", domain.PayeeValidStatus.Value(),
+		"", domain.CPFPixKeyType, "99818083008", nil,
+		domain.RestoreAdditionalPixKey(domain.EmailPixKeyType, "italo@feitosa.com"),
+	)
+
+	assert.Equal(t, []string{"99818083008", "italo@feitosa.com"}, pixKeyValues(payee))
+}
@@ -1,7 +1,9 @@
 package domain
 
 import (
+	"context"
 	"errors"
+	"fmt"
 	"log/slog"
 	"regexp"
 	"strings"
@@ -13,6 +15,8 @@ const (
 	TelefonePixKeyType       = "TELEFONE"
 	EmailPixKeyType          = "EMAIL"
 	ChaveAleatoriaPixKeyType = "CHAVE_ALEATORIA"
+	// AnonymizedPixKeyType is the type of pix key scrubbed from an anonymized payee, NewPixKey rejects it
+	AnonymizedPixKeyType = "ANONYMIZED"
 )
 
 // PixKey is a interface that wraps Brazilian Instant Payment Identification of account
@@ -23,6 +27,8 @@ type PixKey interface {
 	Value() string
 	// Formatted value of pix key
 	String() string
+	// Masked formatted value of pix key, hiding the characters that identify the holder
+	Masked() string
 }
 
 var ErrInvalidPixKeyType = errors.New("invalid pix key type")
@@ -99,19 +105,66 @@ func (tp TelefonePixKey) String() string {
 	return "+" + tp.Value()
 }
 
+// DDD returns the area code of telefone (Ex: 11), empty when telefone is not a valid mobile
+func (tp TelefonePixKey) DDD() string {
+	if len(tp.value) != 13 {
+		return ""
+	}
+
+	return tp.value[2:4]
+}
+
+// UF returns the federative unit of telefone area code
+func (tp TelefonePixKey) UF() UF {
+	uf, _ := DDDState(tp.DDD())
+	return uf
+}
+
+// National returns the telefone in national display format (Ex: (11) 91234-5678),
+// or its value as is when telefone is not a valid mobile
+func (tp TelefonePixKey) National() string {
+	if len(tp.value) != 13 {
+		return tp.value
+	}
+
+	return "(" + tp.DDD() + ") " + tp.value[4:9] + "-" + tp.value[9:]
+}
+
+// Masked returns the telefone keeping country code, area code and last four digits visible (Ex: +55 (11) 9****-5678)
+func (tp TelefonePixKey) Masked() string {
+	v := tp.Value()
+	if len(v) != 13 {
+		return maskAllButLast(tp.String(), 4)
+	}
+
+	return "+" + v[:2] + " (" + v[2:4] + ") " + v[4:5] + "****-" + v[9:]
+}
+
 var (
 	EmptyTelefonePixKey TelefonePixKey
 
 	ErrInvalidTelefone = errors.New("invalid telefone")
+	ErrInvalidDDD      = errors.New("area code (DDD) does not exist")
 
 	TelefoneRegex = regexp.MustCompile(`^((?:\+?55)?)([1-9][0-9])(9[0-9]{8})$`)
+
+	// telefoneSeparators are removed from input before validation, accepting values like +55 (11) 91234-5678
+	telefoneSeparators = strings.NewReplacer(" ", "", "(", "", ")", "", "-", "")
 )
 
+// NewTelefonePixKey returns a mobile telefone pix key, area code must be in Anatel DDD list
 func NewTelefonePixKey(value string) (TelefonePixKey, error) {
-	if !TelefoneRegex.MatchString(value) {
+	value = telefoneSeparators.Replace(strings.TrimSpace(value))
+
+	matches := TelefoneRegex.FindStringSubmatch(value)
+	if matches == nil {
 		return EmptyTelefonePixKey, ErrInvalidTelefone
 	}
 
+	if _, ok := DDDState(matches[2]); !ok {
+		return EmptyTelefonePixKey, fmt.Errorf("%w: %w", ErrInvalidTelefone, ErrInvalidDDD)
+	}
+
 	value = keepOnlyNumbers(value)
 
 	withoutCountryCode := len(value) == 11
@@ -134,6 +187,10 @@ func (e EmailPixKey) String() string {
 	return e.Value()
 }
 
+func (e EmailPixKey) Masked() string {
+	return e.Email.Masked()
+}
+
 var EmptyEmailPixKey EmailPixKey
 
 func NewEmailPixKey(value string) (EmailPixKey, error) {
@@ -179,15 +236,29 @@ func (tp ChaveAleatoriaPixKey) String() string {
 	return tp.Value()
 }
 
-// RestorePixKey shoud be used to restore a instance of PixKey from database
-func RestorePixKey(typ, value string) PixKey {
+// Masked returns the key keeping only the first block and last four characters visible
+// (Ex: 9fcacf81-****-****-****-********9afd)
+func (tp ChaveAleatoriaPixKey) Masked() string {
+	return tp.value[:8] + maskAllButLast(tp.value[8:], 4)
+}
+
+// RestorePixKey shoud be used to restore a instance of PixKey from database,
+// tempered values are logged with ctx, so the warning carries request attributes
+func RestorePixKey(ctx context.Context, typ, value string) PixKey {
+	return restorePixKey(ctx, typ, value)
+}
+
+// restorePixKey restores a pix key, attrs are added to the tempered value warning
+func restorePixKey(ctx context.Context, typ, value string, attrs ...slog.Attr) PixKey {
 	key, err := NewPixKey(typ, value)
 
 	if err != nil {
-		slog.Warn("tempered pix key with invalid values",
+		// pix_key is redacted by logging handler
+		attrs = append(attrs,
 			slog.String("pix_key_type", typ),
 			slog.String("pix_key", value),
 			slog.String("error", err.Error()))
+		slog.LogAttrs(ctx, slog.LevelWarn, "tempered pix key with invalid values", attrs...)
 
 		return restoredPixKey{typ, value}
 	}
@@ -210,3 +281,7 @@ func (r restoredPixKey) Value() string {
 func (r restoredPixKey) String() string {
 	return r.Value()
 }
+
+func (r restoredPixKey) Masked() string {
+	return maskAllButLast(r.value, 4)
+}
@@ -0,0 +1,74 @@
+package domain
+
+import (
+	"errors"
+	"regexp"
+	"strings"
+)
+
+var (
+	ErrAmbiguousPixKey    = errors.New("ambiguous pix key, pix key type must be informed")
+	ErrUndetectablePixKey = errors.New("pix key type could not be detected")
+
+	// documentPunctuationRegex matches CPF and CNPJ punctuation, as dots, slash or dash before check digits
+	documentPunctuationRegex = regexp.MustCompile(`[./]|-[0-9]{2}$`)
+)
+
+// DetectPixKeyType infers the pix key type of a raw value, narrowing the candidate types by rules in order:
+//   - has @, can be EMAIL
+//   - looks like an uuid, can be CHAVE_ALEATORIA
+//   - starts with + or has parentheses or spaces, can be TELEFONE
+//   - has document punctuation (Ex: 000.000.000-00), can be CPF or CNPJ
+//   - otherwise can be CPF, CNPJ or TELEFONE
+//
+// Then the candidate types that accept the value are kept.
+//
+// Unformatted 11 digits can be a valid CPF and a valid TELEFONE at same time,
+// in this case ErrAmbiguousPixKey is returned, as there is no way to choose one of them.
+// When no type accepts the value, ErrUndetectablePixKey is returned
+func DetectPixKeyType(value string) (string, error) {
+	value = strings.TrimSpace(value)
+
+	switch {
+	case strings.Contains(value, "@"):
+		return detectPixKeyTypeFrom(value, EmailPixKeyType)
+	case strings.Count(value, "-") == 4:
+		return detectPixKeyTypeFrom(value, ChaveAleatoriaPixKeyType)
+	case strings.HasPrefix(value, "+") || strings.ContainsAny(value, "() "):
+		return detectPixKeyTypeFrom(value, TelefonePixKeyType)
+	case documentPunctuationRegex.MatchString(value):
+		return detectPixKeyTypeFrom(value, CPFPixKeyType, CNPJPixKeyType)
+	default:
+		return detectPixKeyTypeFrom(value, CPFPixKeyType, CNPJPixKeyType, TelefonePixKeyType)
+	}
+}
+
+// detectPixKeyTypeFrom returns the only candidate type that accepts value
+func detectPixKeyTypeFrom(value string, candidates ...string) (string, error) {
+	var accepted []string
+
+	for _, typ := range candidates {
+		if _, err := NewPixKey(typ, value); err == nil {
+			accepted = append(accepted, typ)
+		}
+	}
+
+	switch len(accepted) {
+	case 0:
+		return "", ErrUndetectablePixKey
+	case 1:
+		return accepted[0], nil
+	default:
+		return "", ErrAmbiguousPixKey
+	}
+}
+
+// DetectPixKey creates a PixKey inferring its type with DetectPixKeyType
+func DetectPixKey(value string) (PixKey, error) {
+	typ, err := DetectPixKeyType(value)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewPixKey(typ, value)
+}
@@ -0,0 +1,104 @@
+package domain_test
+
+import (
+	"testing"
+
+	"github.com/italorfeitosa/payee-account-manager-api/internal/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDetectPixKeyType(t *testing.T) {
+	tests := []struct {
+		name    string
+		arg     string
+		want    string
+		wantErr error
+	}{
+		{
+			name: "given an email should detect EMAIL",
+			arg:  "italo@feitosa.com",
+			want: domain.EmailPixKeyType,
+		},
+		{
+			name: "given an uuid should detect CHAVE_ALEATORIA",
+			arg:  "9fcacf81-0f7f-47a2-881d-da4f41a39afd",
+			want: domain.ChaveAleatoriaPixKeyType,
+		},
+		{
+			name: "given a telefone with country code should detect TELEFONE",
+			arg:  "+5511998180830",
+			want: domain.TelefonePixKeyType,
+		},
+		{
+			name: "given a telefone with area code in parentheses should detect TELEFONE",
+			arg:  "(11) 91234-5678",
+			want: domain.TelefonePixKeyType,
+		},
+		{
+			name: "given a telefone without country code should detect TELEFONE",
+			arg:  "11912345678",
+			want: domain.TelefonePixKeyType,
+		},
+		{
+			name: "given a formatted cpf should detect CPF",
+			arg:  "998.180.830-08",
+			want: domain.CPFPixKeyType,
+		},
+		{
+			name: "given a cpf with dash before check digits should detect CPF",
+			arg:  "998180830-08",
+			want: domain.CPFPixKeyType,
+		},
+		{
+			name: "given an unformatted cpf that is not a telefone should detect CPF",
+			arg:  "77386735081",
+			want: domain.CPFPixKeyType,
+		},
+		{
+			name: "given a cnpj should detect CNPJ",
+			arg:  "19039318000104",
+			want: domain.CNPJPixKeyType,
+		},
+		{
+			name: "given a formatted cnpj should detect CNPJ",
+			arg:  "35.952.585/0001-24",
+			want: domain.CNPJPixKeyType,
+		},
+		{
+			name: "given an alphanumeric cnpj should detect CNPJ",
+			arg:  "12ABC34501DE35",
+			want: domain.CNPJPixKeyType,
+		},
+		{
+			name:    "given unformatted digits valid as cpf and telefone should return ambiguous error",
+			arg:     "11987654374",
+			wantErr: domain.ErrAmbiguousPixKey,
+		},
+		{
+			name:    "given a value that does not match any type should return error",
+			arg:     "not a key",
+			wantErr: domain.ErrUndetectablePixKey,
+		},
+		{
+			name:    "given digits that does not match any type should return error",
+			arg:     "12345",
+			wantErr: domain.ErrUndetectablePixKey,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := domain.DetectPixKeyType(tt.arg)
+
+			assert.Equal(t, tt.want, got)
+			assert.ErrorIs(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestDetectPixKey(t *testing.T) {
+	got, err := domain.DetectPixKey("998.180.830-08")
+
+	assert.NoError(t, err)
+	assert.Equal(t, domain.CPFPixKeyType, got.Type())
+	assert.Equal(t, "99818083008", got.Value())
+}
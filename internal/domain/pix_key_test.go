@@ -1,6 +1,7 @@
 package domain_test
 
 import (
+	"context"
 	"testing"
 
 	"github.com/italorfeitosa/payee-account-manager-api/internal/domain"
@@ -50,6 +51,16 @@ func TestNewPixKey(t *testing.T) {
 			wantValue:  "65678974000174",
 			wantString: "65.678.974/0001-74",
 		},
+		{
+			name: "given a valid alphanumeric CNPJ key should return a CNPJ pix key",
+			args: args{
+				typ:   domain.CNPJPixKeyType,
+				value: "12.ABC.345/01DE-35",
+			},
+			wantType:   domain.CNPJPixKeyType,
+			wantValue:  "12ABC34501DE35",
+			wantString: "12.ABC.345/01DE-35",
+		},
 		{
 			name: "given an invalid CNPJ key should return error",
 			args: args{
@@ -98,6 +109,42 @@ func TestNewPixKey(t *testing.T) {
 			wantValue:  "5599987654321",
 			wantString: "+5599987654321",
 		},
+		{
+			name: "given a Telefone key with separators should return a Telefone pix key",
+			args: args{
+				typ:   domain.TelefonePixKeyType,
+				value: "+55 (11) 91234-5678",
+			},
+			wantType:   domain.TelefonePixKeyType,
+			wantValue:  "5511912345678",
+			wantString: "+5511912345678",
+		},
+		{
+			name: "given a Telefone key with area code and separators should return a Telefone pix key",
+			args: args{
+				typ:   domain.TelefonePixKeyType,
+				value: "(21) 98765-4321",
+			},
+			wantType:   domain.TelefonePixKeyType,
+			wantValue:  "5521987654321",
+			wantString: "+5521987654321",
+		},
+		{
+			name: "given a Telefone key when area code does not exist should return error",
+			args: args{
+				typ:   domain.TelefonePixKeyType,
+				value: "+5520987654321",
+			},
+			wantErr: domain.ErrInvalidDDD,
+		},
+		{
+			name: "given a Telefone key without country code when area code does not exist should return invalid telefone error",
+			args: args{
+				typ:   domain.TelefonePixKeyType,
+				value: "23987654321",
+			},
+			wantErr: domain.ErrInvalidTelefone,
+		},
 		{
 			name: "given an invalid Telefone key should return error",
 			args: args{
@@ -165,3 +212,88 @@ func TestNewPixKey(t *testing.T) {
 		})
 	}
 }
+
+func TestPixKey_Masked(t *testing.T) {
+	tests := []struct {
+		name  string
+		typ   string
+		value string
+		want  string
+	}{
+		{
+			name:  "given a cpf key should hide first and check digits",
+			typ:   domain.CPFPixKeyType,
+			value: "99818083008",
+			want:  "***.180.830-**",
+		},
+		{
+			name:  "given a cnpj key should hide first and check digits",
+			typ:   domain.CNPJPixKeyType,
+			value: "65678974000174",
+			want:  "**.678.974/0001-**",
+		},
+		{
+			name:  "given a telefone key should keep area code and last digits",
+			typ:   domain.TelefonePixKeyType,
+			value: "+5511912345678",
+			want:  "+55 (11) 9****-5678",
+		},
+		{
+			name:  "given an email key should keep first letter and domain",
+			typ:   domain.EmailPixKeyType,
+			value: "italo@feitosa.com",
+			want:  "i***@feitosa.com",
+		},
+		{
+			name:  "given a chave aleatoria key should keep first block and last characters",
+			typ:   domain.ChaveAleatoriaPixKeyType,
+			value: "9fcacf81-0f7f-47a2-881d-da4f41a39afd",
+			want:  "9fcacf81-****-****-****-********9afd",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := domain.NewPixKey(tt.typ, tt.value)
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got.Masked())
+		})
+	}
+
+	t.Run("given a tempered pix key should keep only last characters", func(t *testing.T) {
+		got := domain.RestorePixKey(context.Background(), domain.CPFPixKeyType, "123456789")
+
+		assert.Equal(t, "*****6789", got.Masked())
+	})
+}
+
+func TestTelefonePixKey_National(t *testing.T) {
+	tests := []struct {
+		value        string
+		wantDDD      string
+		wantUF       domain.UF
+		wantNational string
+	}{
+		{value: "+5511912345678", wantDDD: "11", wantUF: domain.UFSP, wantNational: "(11) 91234-5678"},
+		{value: "61 99876-5432", wantDDD: "61", wantUF: domain.UFDF, wantNational: "(61) 99876-5432"},
+		{value: "99987654321", wantDDD: "99", wantUF: domain.UFMA, wantNational: "(99) 98765-4321"},
+	}
+	for _, tt := range tests {
+		t.Run("given "+tt.value+" should return national format", func(t *testing.T) {
+			got, err := domain.NewTelefonePixKey(tt.value)
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantDDD, got.DDD())
+			assert.Equal(t, tt.wantUF, got.UF())
+			assert.Equal(t, tt.wantNational, got.National())
+		})
+	}
+
+	t.Run("given an empty telefone should not panic", func(t *testing.T) {
+		var got domain.TelefonePixKey
+
+		assert.Equal(t, "", got.DDD())
+		assert.Equal(t, domain.UF(""), got.UF())
+		assert.Equal(t, "", got.National())
+	})
+}
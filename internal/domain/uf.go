@@ -0,0 +1,64 @@
+package domain
+
+import (
+	"errors"
+	"strings"
+)
+
+// UF is the abbreviation of a Brazilian federative unit (states and Distrito Federal)
+type UF string
+
+const (
+	UFAC UF = "AC"
+	UFAL UF = "AL"
+	UFAP UF = "AP"
+	UFAM UF = "AM"
+	UFBA UF = "BA"
+	UFCE UF = "CE"
+	UFDF UF = "DF"
+	UFES UF = "ES"
+	UFGO UF = "GO"
+	UFMA UF = "MA"
+	UFMT UF = "MT"
+	UFMS UF = "MS"
+	UFMG UF = "MG"
+	UFPA UF = "PA"
+	UFPB UF = "PB"
+	UFPR UF = "PR"
+	UFPE UF = "PE"
+	UFPI UF = "PI"
+	UFRJ UF = "RJ"
+	UFRN UF = "RN"
+	UFRS UF = "RS"
+	UFRO UF = "RO"
+	UFRR UF = "RR"
+	UFSC UF = "SC"
+	UFSP UF = "SP"
+	UFSE UF = "SE"
+	UFTO UF = "TO"
+)
+
+// UFs lists all 27 federative units
+var UFs = []UF{
+	UFAC, UFAL, UFAP, UFAM, UFBA, UFCE, UFDF, UFES, UFGO, UFMA, UFMT, UFMS, UFMG, UFPA,
+	UFPB, UFPR, UFPE, UFPI, UFRJ, UFRN, UFRS, UFRO, UFRR, UFSC, UFSP, UFSE, UFTO,
+}
+
+var ErrInvalidUF = errors.New("invalid uf")
+
+// NewUF returns the UF matching the abbreviation, case insensitive
+func NewUF(v string) (UF, error) {
+	abbreviation := UF(strings.ToUpper(strings.TrimSpace(v)))
+
+	for _, uf := range UFs {
+		if uf == abbreviation {
+			return uf, nil
+		}
+	}
+
+	return "", ErrInvalidUF
+}
+
+func (uf UF) String() string {
+	return string(uf)
+}
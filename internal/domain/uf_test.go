@@ -0,0 +1,50 @@
+package domain_test
+
+import (
+	"testing"
+
+	"github.com/italorfeitosa/payee-account-manager-api/internal/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewUF(t *testing.T) {
+	tests := []struct {
+		name    string
+		arg     string
+		want    domain.UF
+		wantErr error
+	}{
+		{
+			name: "given a valid uf should return UF",
+			arg:  "SP",
+			want: domain.UFSP,
+		},
+		{
+			name: "given a valid uf in lower case should return UF",
+			arg:  " df ",
+			want: domain.UFDF,
+		},
+		{
+			name:    "given an unknown uf should return error",
+			arg:     "XX",
+			wantErr: domain.ErrInvalidUF,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := domain.NewUF(tt.arg)
+
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, tt.wantErr, err)
+		})
+	}
+}
+
+func TestDDDState(t *testing.T) {
+	uf, ok := domain.DDDState("11")
+	assert.True(t, ok)
+	assert.Equal(t, domain.UFSP, uf)
+
+	_, ok = domain.DDDState("20")
+	assert.False(t, ok)
+}
@@ -0,0 +1,4 @@
+// encryption package protects personal fields at rest with AES-GCM envelope encryption, where
+// each record has its own data key wrapped by a key encryption key of a KeyProvider, and
+// computes HMAC blind indexes so encrypted fields can still be matched exactly
+package encryption
@@ -0,0 +1,134 @@
+package encryption
+
+import (
+	"context"
+	"crypto/cipher"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+)
+
+// WrappedKey is a data key encrypted by key encryption key KeyID, stored next to the fields it encrypts
+type WrappedKey struct {
+	KeyID      string
+	Ciphertext []byte
+}
+
+// DataKey encrypts the fields of a single record
+type DataKey struct {
+	aead    cipher.AEAD
+	wrapped WrappedKey
+}
+
+// Wrapped returns the data key encrypted, to be stored with the record
+func (k *DataKey) Wrapped() WrappedKey {
+	return k.wrapped
+}
+
+// Encrypt returns plaintext encrypted, associatedData is authenticated but not stored, so
+// decryption fails when it differs. It should identify where ciphertext is stored
+// (Ex: tenant, record id and field), so ciphertexts can't be moved to other records
+func (k *DataKey) Encrypt(plaintext, associatedData string) ([]byte, error) {
+	return seal(k.aead, []byte(plaintext), []byte(associatedData))
+}
+
+// Decrypt returns the plaintext of a ciphertext returned by Encrypt with the same associatedData
+func (k *DataKey) Decrypt(ciphertext []byte, associatedData string) (string, error) {
+	plaintext, err := open(k.aead, ciphertext, []byte(associatedData))
+	if err != nil {
+		return "", fmt.Errorf("decrypt field: %w", err)
+	}
+
+	return string(plaintext), nil
+}
+
+// FieldEncrypter creates and opens data keys of records, and computes blind indexes of their fields
+type FieldEncrypter struct {
+	keys KeyProvider
+}
+
+func NewFieldEncrypter(keys KeyProvider) *FieldEncrypter {
+	return &FieldEncrypter{keys}
+}
+
+// NewDataKey returns a random data key wrapped by current key encryption key
+func (e *FieldEncrypter) NewDataKey(ctx context.Context) (*DataKey, error) {
+	key := make([]byte, KeySize)
+	if _, err := rand.Read(key); err != nil {
+		return nil, err
+	}
+
+	keyID, wrapped, err := e.keys.WrapKey(ctx, key)
+	if err != nil {
+		return nil, fmt.Errorf("wrap data key: %w", err)
+	}
+
+	aead, err := newAEAD(key)
+	if err != nil {
+		return nil, err
+	}
+
+	return &DataKey{aead, WrappedKey{keyID, wrapped}}, nil
+}
+
+// OpenDataKey unwraps a stored data key
+func (e *FieldEncrypter) OpenDataKey(ctx context.Context, wrapped WrappedKey) (*DataKey, error) {
+	key, err := e.keys.UnwrapKey(ctx, wrapped.KeyID, wrapped.Ciphertext)
+	if err != nil {
+		return nil, fmt.Errorf("unwrap data key: %w", err)
+	}
+
+	aead, err := newAEAD(key)
+	if err != nil {
+		return nil, err
+	}
+
+	return &DataKey{aead, wrapped}, nil
+}
+
+// Rewrap returns the data key wrapped by current key encryption key, and false when it already is.
+// Fields are not encrypted again, as the data key itself does not change
+func (e *FieldEncrypter) Rewrap(ctx context.Context, wrapped WrappedKey) (WrappedKey, bool, error) {
+	currentKeyID, err := e.keys.CurrentKeyID(ctx)
+	if err != nil {
+		return WrappedKey{}, false, err
+	}
+
+	if wrapped.KeyID == currentKeyID {
+		return wrapped, false, nil
+	}
+
+	key, err := e.keys.UnwrapKey(ctx, wrapped.KeyID, wrapped.Ciphertext)
+	if err != nil {
+		return WrappedKey{}, false, fmt.Errorf("unwrap data key: %w", err)
+	}
+
+	keyID, ciphertext, err := e.keys.WrapKey(ctx, key)
+	if err != nil {
+		return WrappedKey{}, false, fmt.Errorf("wrap data key: %w", err)
+	}
+
+	return WrappedKey{keyID, ciphertext}, true, nil
+}
+
+// BlindIndex returns the HMAC-SHA256 of value, in hex, to match encrypted fields by exact value.
+// Tenant and field are part of the index, so equal values of other tenants or fields don't
+// share the index. Empty values have empty index, as there is nothing to match
+func (e *FieldEncrypter) BlindIndex(ctx context.Context, tenantID, field, value string) (string, error) {
+	if value == "" {
+		return "", nil
+	}
+
+	key, err := e.keys.IndexKey(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	mac := hmac.New(sha256.New, key)
+	// zero bytes separate parts, so ("ab", "c") and ("a", "bc") differ
+	mac.Write([]byte(tenantID + "\x00" + field + "\x00" + value))
+
+	return hex.EncodeToString(mac.Sum(nil)), nil
+}
@@ -0,0 +1,135 @@
+package encryption_test
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"testing"
+
+	"github.com/italorfeitosa/payee-account-manager-api/internal/infra/encryption"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func randomKey(t *testing.T) []byte {
+	t.Helper()
+
+	key := make([]byte, encryption.KeySize)
+	_, err := rand.Read(key)
+	require.NoError(t, err)
+
+	return key
+}
+
+func TestFieldEncrypter(t *testing.T) {
+	ctx := context.Background()
+	indexKey := randomKey(t)
+	oldKey, newKey := randomKey(t), randomKey(t)
+
+	before, err := encryption.NewKeyRing("old", map[string][]byte{"old": oldKey}, indexKey)
+	require.NoError(t, err)
+	after, err := encryption.NewKeyRing("new", map[string][]byte{"old": oldKey, "new": newKey}, indexKey)
+	require.NoError(t, err)
+
+	encrypter := encryption.NewFieldEncrypter(before)
+	dataKey, err := encrypter.NewDataKey(ctx)
+	require.NoError(t, err)
+
+	ciphertext, err := dataKey.Encrypt("99818083008", "tenant/payee/cpf_cnpj")
+	require.NoError(t, err)
+
+	t.Run("given ciphertext should not contain plaintext", func(t *testing.T) {
+		assert.False(t, bytes.Contains(ciphertext, []byte("99818083008")))
+	})
+
+	t.Run("given same plaintext should encrypt with another nonce", func(t *testing.T) {
+		other, err := dataKey.Encrypt("99818083008", "tenant/payee/cpf_cnpj")
+
+		require.NoError(t, err)
+		assert.NotEqual(t, ciphertext, other)
+	})
+
+	t.Run("given stored data key should decrypt field", func(t *testing.T) {
+		opened, err := encrypter.OpenDataKey(ctx, dataKey.Wrapped())
+		require.NoError(t, err)
+
+		got, err := opened.Decrypt(ciphertext, "tenant/payee/cpf_cnpj")
+
+		require.NoError(t, err)
+		assert.Equal(t, "99818083008", got)
+	})
+
+	t.Run("given other associated data should not decrypt field", func(t *testing.T) {
+		_, err := dataKey.Decrypt(ciphertext, "tenant/other-payee/cpf_cnpj")
+
+		assert.Error(t, err)
+	})
+
+	t.Run("given rotated keys should open data keys wrapped by previous key", func(t *testing.T) {
+		rotated := encryption.NewFieldEncrypter(after)
+
+		opened, err := rotated.OpenDataKey(ctx, dataKey.Wrapped())
+		require.NoError(t, err)
+
+		got, err := opened.Decrypt(ciphertext, "tenant/payee/cpf_cnpj")
+		require.NoError(t, err)
+		assert.Equal(t, "99818083008", got)
+	})
+
+	t.Run("given rotated keys should rewrap data key with current key", func(t *testing.T) {
+		rotated := encryption.NewFieldEncrypter(after)
+
+		rewrapped, ok, err := rotated.Rewrap(ctx, dataKey.Wrapped())
+		require.NoError(t, err)
+		require.True(t, ok)
+		assert.Equal(t, "new", rewrapped.KeyID)
+
+		opened, err := rotated.OpenDataKey(ctx, rewrapped)
+		require.NoError(t, err)
+		got, err := opened.Decrypt(ciphertext, "tenant/payee/cpf_cnpj")
+		require.NoError(t, err)
+		assert.Equal(t, "99818083008", got)
+
+		_, ok, err = rotated.Rewrap(ctx, rewrapped)
+		require.NoError(t, err)
+		assert.False(t, ok)
+	})
+
+	t.Run("given removed key should not open data key", func(t *testing.T) {
+		removed, err := encryption.NewKeyRing("new", map[string][]byte{"new": newKey}, indexKey)
+		require.NoError(t, err)
+
+		_, err = encryption.NewFieldEncrypter(removed).OpenDataKey(ctx, dataKey.Wrapped())
+
+		assert.ErrorIs(t, err, encryption.ErrKeyNotFound)
+	})
+}
+
+func TestFieldEncrypter_BlindIndex(t *testing.T) {
+	ctx := context.Background()
+	ring, err := encryption.NewKeyRing("key", map[string][]byte{"key": randomKey(t)}, randomKey(t))
+	require.NoError(t, err)
+	encrypter := encryption.NewFieldEncrypter(ring)
+
+	index := func(tenantID, field, value string) string {
+		got, err := encrypter.BlindIndex(ctx, tenantID, field, value)
+		require.NoError(t, err)
+		return got
+	}
+
+	t.Run("given same value should return same index", func(t *testing.T) {
+		assert.Equal(t, index("tenant", "cpf_cnpj", "99818083008"), index("tenant", "cpf_cnpj", "99818083008"))
+	})
+
+	t.Run("given other tenant, field or value should return other index", func(t *testing.T) {
+		base := index("tenant", "cpf_cnpj", "99818083008")
+
+		assert.NotEqual(t, base, index("other", "cpf_cnpj", "99818083008"))
+		assert.NotEqual(t, base, index("tenant", "pix_key", "99818083008"))
+		assert.NotEqual(t, base, index("tenant", "cpf_cnpj", "77386735081"))
+	})
+
+	t.Run("given empty value should return empty index", func(t *testing.T) {
+		assert.Empty(t, index("tenant", "account_number", ""))
+	})
+}
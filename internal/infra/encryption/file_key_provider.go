@@ -0,0 +1,111 @@
+package encryption
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sync/atomic"
+)
+
+type keyFile struct {
+	CurrentKeyID string            `json:"current_key_id"`
+	Keys         map[string]string `json:"keys"`
+	IndexKey     string            `json:"index_key"`
+}
+
+// FileKeyProvider is a KeyProvider for local runs, which loads base64 keys from a json file:
+//
+//	{
+//	    "current_key_id": "2024-06",
+//	    "keys": {"2024-01": "<32 bytes in base64>", "2024-06": "<32 bytes in base64>"},
+//	    "index_key": "<32 bytes in base64>"
+//	}
+//
+// Keys rotate without downtime: add a new key, point current_key_id to it and call Reload.
+// New data keys are wrapped by the new key, while data keys wrapped by previous keys are
+// still unwrapped, so previous keys must stay in file until every data key is rewrapped
+type FileKeyProvider struct {
+	path string
+	ring atomic.Pointer[KeyRing]
+}
+
+var _ KeyProvider = (*FileKeyProvider)(nil)
+
+// NewFileKeyProvider loads keys from file at path
+func NewFileKeyProvider(path string) (*FileKeyProvider, error) {
+	provider := &FileKeyProvider{path: path}
+
+	ring, err := provider.load()
+	if err != nil {
+		return nil, err
+	}
+
+	provider.ring.Store(ring)
+
+	return provider, nil
+}
+
+// Reload reads file again and replaces the keys, in-flight operations keep the previous ones.
+// On error, or when index key changed, previous keys are kept
+func (p *FileKeyProvider) Reload() error {
+	ring, err := p.load()
+	if err != nil {
+		return err
+	}
+
+	if !bytes.Equal(ring.indexKey, p.ring.Load().indexKey) {
+		return fmt.Errorf("%w: index key can't change, as stored blind indexes would stop matching", ErrInvalidKey)
+	}
+
+	p.ring.Store(ring)
+
+	return nil
+}
+
+func (p *FileKeyProvider) load() (*KeyRing, error) {
+	content, err := os.ReadFile(p.path)
+	if err != nil {
+		return nil, fmt.Errorf("read encryption keys file: %w", err)
+	}
+
+	var file keyFile
+	if err := json.Unmarshal(content, &file); err != nil {
+		return nil, fmt.Errorf("decode encryption keys file: %w", err)
+	}
+
+	keys := make(map[string][]byte, len(file.Keys))
+	for id, encoded := range file.Keys {
+		key, err := base64.StdEncoding.DecodeString(encoded)
+		if err != nil {
+			return nil, fmt.Errorf("%w: key %q is not base64", ErrInvalidKey, id)
+		}
+
+		keys[id] = key
+	}
+
+	indexKey, err := base64.StdEncoding.DecodeString(file.IndexKey)
+	if err != nil {
+		return nil, fmt.Errorf("%w: index key is not base64", ErrInvalidKey)
+	}
+
+	return NewKeyRing(file.CurrentKeyID, keys, indexKey)
+}
+
+func (p *FileKeyProvider) WrapKey(ctx context.Context, dataKey []byte) (string, []byte, error) {
+	return p.ring.Load().WrapKey(ctx, dataKey)
+}
+
+func (p *FileKeyProvider) UnwrapKey(ctx context.Context, keyID string, wrapped []byte) ([]byte, error) {
+	return p.ring.Load().UnwrapKey(ctx, keyID, wrapped)
+}
+
+func (p *FileKeyProvider) CurrentKeyID(ctx context.Context) (string, error) {
+	return p.ring.Load().CurrentKeyID(ctx)
+}
+
+func (p *FileKeyProvider) IndexKey(ctx context.Context) ([]byte, error) {
+	return p.ring.Load().IndexKey(ctx)
+}
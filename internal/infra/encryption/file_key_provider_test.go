@@ -0,0 +1,91 @@
+package encryption_test
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/italorfeitosa/payee-account-manager-api/internal/infra/encryption"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeKeyFile(t *testing.T, path, current string, keys map[string][]byte, indexKey []byte) {
+	t.Helper()
+
+	encoded := make(map[string]string, len(keys))
+	for id, key := range keys {
+		encoded[id] = base64.StdEncoding.EncodeToString(key)
+	}
+
+	content, err := json.Marshal(map[string]any{
+		"current_key_id": current,
+		"keys":           encoded,
+		"index_key":      base64.StdEncoding.EncodeToString(indexKey),
+	})
+	require.NoError(t, err)
+
+	require.NoError(t, os.WriteFile(path, content, 0o600))
+}
+
+func TestFileKeyProvider(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "keys.json")
+	indexKey := randomKey(t)
+	oldKey, newKey := randomKey(t), randomKey(t)
+
+	writeKeyFile(t, path, "old", map[string][]byte{"old": oldKey}, indexKey)
+	provider, err := encryption.NewFileKeyProvider(path)
+	require.NoError(t, err)
+
+	keyID, wrapped, err := provider.WrapKey(ctx, randomKey(t))
+	require.NoError(t, err)
+	require.Equal(t, "old", keyID)
+
+	t.Run("given reload with invalid file should keep previous keys", func(t *testing.T) {
+		writeKeyFile(t, path, "missing", map[string][]byte{"old": oldKey}, indexKey)
+
+		assert.ErrorIs(t, provider.Reload(), encryption.ErrInvalidKey)
+
+		current, err := provider.CurrentKeyID(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, "old", current)
+	})
+
+	t.Run("given reload with another index key should keep previous keys", func(t *testing.T) {
+		writeKeyFile(t, path, "old", map[string][]byte{"old": oldKey}, randomKey(t))
+
+		assert.ErrorIs(t, provider.Reload(), encryption.ErrInvalidKey)
+	})
+
+	t.Run("given reload with new current key should wrap with it and unwrap with previous", func(t *testing.T) {
+		writeKeyFile(t, path, "new", map[string][]byte{"old": oldKey, "new": newKey}, indexKey)
+
+		require.NoError(t, provider.Reload())
+
+		current, err := provider.CurrentKeyID(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, "new", current)
+
+		_, err = provider.UnwrapKey(ctx, "old", wrapped)
+		assert.NoError(t, err)
+	})
+
+	t.Run("given short key should return error", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "keys.json")
+		writeKeyFile(t, path, "short", map[string][]byte{"short": []byte("short")}, indexKey)
+
+		_, err := encryption.NewFileKeyProvider(path)
+
+		assert.ErrorIs(t, err, encryption.ErrInvalidKey)
+	})
+
+	t.Run("given missing file should return error", func(t *testing.T) {
+		_, err := encryption.NewFileKeyProvider("/not/found.json")
+
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,126 @@
+package encryption
+
+import (
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"errors"
+	"fmt"
+)
+
+// KeySize is the size of key encryption keys and data keys, as AES-256 requires
+const KeySize = 32
+
+var (
+	ErrInvalidKey  = errors.New("invalid encryption key")
+	ErrKeyNotFound = errors.New("encryption key not found")
+)
+
+// KeyProvider holds the key encryption keys, it is the port to local keys or to a KMS.
+// Keys are never returned, so a KMS can wrap and unwrap data keys without exposing them
+type KeyProvider interface {
+	// WrapKey encrypts a data key with the current key encryption key, and returns its id
+	WrapKey(ctx context.Context, dataKey []byte) (keyID string, wrapped []byte, err error)
+	// UnwrapKey decrypts a data key wrapped by key encryption key keyID, which may not be current
+	// anymore after a rotation. It returns ErrKeyNotFound when keyID was removed
+	UnwrapKey(ctx context.Context, keyID string, wrapped []byte) ([]byte, error)
+	// CurrentKeyID returns the id of the key that wraps new data keys
+	CurrentKeyID(ctx context.Context) (string, error)
+	// IndexKey returns the HMAC key of blind indexes, unlike key encryption keys
+	// it can't rotate without recomputing every stored index
+	IndexKey(ctx context.Context) ([]byte, error)
+}
+
+// KeyRing is a KeyProvider with keys held in process memory
+type KeyRing struct {
+	currentKeyID string
+	keys         map[string]cipher.AEAD
+	indexKey     []byte
+}
+
+var _ KeyProvider = (*KeyRing)(nil)
+
+// NewKeyRing returns the key ring, keys are keyed by id and must have KeySize bytes,
+// currentKeyID must be one of them and indexKey must have at least KeySize bytes
+func NewKeyRing(currentKeyID string, keys map[string][]byte, indexKey []byte) (*KeyRing, error) {
+	if _, ok := keys[currentKeyID]; !ok {
+		return nil, fmt.Errorf("%w: current key %q is not in keys", ErrInvalidKey, currentKeyID)
+	}
+
+	if len(indexKey) < KeySize {
+		return nil, fmt.Errorf("%w: index key must have at least %d bytes", ErrInvalidKey, KeySize)
+	}
+
+	ring := &KeyRing{currentKeyID: currentKeyID, keys: make(map[string]cipher.AEAD, len(keys)), indexKey: indexKey}
+	for id, key := range keys {
+		aead, err := newAEAD(key)
+		if err != nil {
+			return nil, fmt.Errorf("key %q: %w", id, err)
+		}
+
+		ring.keys[id] = aead
+	}
+
+	return ring, nil
+}
+
+func (r *KeyRing) WrapKey(_ context.Context, dataKey []byte) (string, []byte, error) {
+	wrapped, err := seal(r.keys[r.currentKeyID], dataKey, []byte(r.currentKeyID))
+	if err != nil {
+		return "", nil, err
+	}
+
+	return r.currentKeyID, wrapped, nil
+}
+
+func (r *KeyRing) UnwrapKey(_ context.Context, keyID string, wrapped []byte) ([]byte, error) {
+	aead, ok := r.keys[keyID]
+	if !ok {
+		return nil, fmt.Errorf("%w: %q", ErrKeyNotFound, keyID)
+	}
+
+	// key id is authenticated, so a data key can't be presented as wrapped by another key
+	return open(aead, wrapped, []byte(keyID))
+}
+
+func (r *KeyRing) CurrentKeyID(_ context.Context) (string, error) {
+	return r.currentKeyID, nil
+}
+
+func (r *KeyRing) IndexKey(_ context.Context) ([]byte, error) {
+	return r.indexKey, nil
+}
+
+func newAEAD(key []byte) (cipher.AEAD, error) {
+	if len(key) != KeySize {
+		return nil, fmt.Errorf("%w: key must have %d bytes", ErrInvalidKey, KeySize)
+	}
+
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+
+	return cipher.NewGCM(block)
+}
+
+// seal encrypts plaintext with a random nonce, which prefixes the returned ciphertext
+func seal(aead cipher.AEAD, plaintext, associatedData []byte) ([]byte, error) {
+	nonce := make([]byte, aead.NonceSize(), aead.NonceSize()+len(plaintext)+aead.Overhead())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, err
+	}
+
+	return aead.Seal(nonce, nonce, plaintext, associatedData), nil
+}
+
+func open(aead cipher.AEAD, ciphertext, associatedData []byte) ([]byte, error) {
+	if len(ciphertext) < aead.NonceSize() {
+		return nil, errors.New("ciphertext is too short")
+	}
+
+	nonce, sealed := ciphertext[:aead.NonceSize()], ciphertext[aead.NonceSize():]
+
+	return aead.Open(nil, nonce, sealed, associatedData)
+}
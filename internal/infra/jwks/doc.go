@@ -0,0 +1,3 @@
+// jwks package loads JSON Web Key Sets, the public keys which verify
+// the signature of api bearer tokens
+package jwks
@@ -0,0 +1,198 @@
+package jwks
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+var (
+	ErrInvalidKeySet = errors.New("invalid key set")
+	ErrKeyNotFound   = errors.New("key not found")
+)
+
+// jsonWebKey holds the RFC 7517 members of RSA, EC and OKP public keys
+type jsonWebKey struct {
+	KeyType   string `json:"kty"`
+	KeyID     string `json:"kid"`
+	Use       string `json:"use"`
+	Algorithm string `json:"alg"`
+	// N and E are RSA modulus and exponent
+	N string `json:"n"`
+	E string `json:"e"`
+	// Curve, X and Y are EC and OKP (Ed25519) points, OKP keys have no Y
+	Curve string `json:"crv"`
+	X     string `json:"x"`
+	Y     string `json:"y"`
+}
+
+type publicKey struct {
+	key       crypto.PublicKey
+	algorithm string
+}
+
+// KeySet is the set of public signing keys of a JWKS document, keyed by kid
+type KeySet struct {
+	keys map[string]publicKey
+}
+
+// LoadFile reads the JWKS document at path, like:
+//
+//	{"keys": [{"kty": "RSA", "kid": "2024-01", "use": "sig", "alg": "RS256", "n": "...", "e": "AQAB"}]}
+func LoadFile(path string) (*KeySet, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read jwks file: %w", err)
+	}
+
+	return Parse(content)
+}
+
+// Parse decodes a JWKS document, keys whose use is not "sig" are ignored
+// and every signing key must have a distinct kid
+func Parse(content []byte) (*KeySet, error) {
+	var document struct {
+		Keys []jsonWebKey `json:"keys"`
+	}
+	if err := json.Unmarshal(content, &document); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidKeySet, err)
+	}
+
+	keys := make(map[string]publicKey, len(document.Keys))
+	for i, jwk := range document.Keys {
+		if jwk.Use != "" && jwk.Use != "sig" {
+			continue
+		}
+
+		if _, ok := keys[jwk.KeyID]; ok {
+			return nil, fmt.Errorf("%w: duplicated kid %q", ErrInvalidKeySet, jwk.KeyID)
+		}
+
+		key, err := jwk.publicKey()
+		if err != nil {
+			return nil, fmt.Errorf("%w: key %d: %w", ErrInvalidKeySet, i, err)
+		}
+
+		keys[jwk.KeyID] = publicKey{key, jwk.Algorithm}
+	}
+
+	if len(keys) == 0 {
+		return nil, fmt.Errorf("%w: no signing keys", ErrInvalidKeySet)
+	}
+
+	return &KeySet{keys}, nil
+}
+
+// Keyfunc returns the key of token kid, a token without kid is only accepted when set
+// has a single key. Keys with alg only verify tokens signed with that algorithm
+func (s *KeySet) Keyfunc(token *jwt.Token) (any, error) {
+	kid, _ := token.Header["kid"].(string)
+
+	key, ok := s.keys[kid]
+	if !ok && kid == "" && len(s.keys) == 1 {
+		for _, k := range s.keys {
+			key, ok = k, true
+		}
+	}
+	if !ok {
+		return nil, fmt.Errorf("%w: kid %q", ErrKeyNotFound, kid)
+	}
+
+	if key.algorithm != "" && key.algorithm != token.Method.Alg() {
+		return nil, fmt.Errorf("%w: kid %q does not sign %s", ErrKeyNotFound, kid, token.Method.Alg())
+	}
+
+	return key.key, nil
+}
+
+func (k jsonWebKey) publicKey() (crypto.PublicKey, error) {
+	switch k.KeyType {
+	case "RSA":
+		n, err := decodeInt(k.N)
+		if err != nil {
+			return nil, fmt.Errorf("n: %w", err)
+		}
+		e, err := decodeInt(k.E)
+		if err != nil {
+			return nil, fmt.Errorf("e: %w", err)
+		}
+		if !e.IsInt64() || e.Int64() > 1<<31-1 {
+			return nil, errors.New("e is too large")
+		}
+
+		return &rsa.PublicKey{N: n, E: int(e.Int64())}, nil
+	case "EC":
+		curve, err := ellipticCurve(k.Curve)
+		if err != nil {
+			return nil, err
+		}
+		x, err := decodeInt(k.X)
+		if err != nil {
+			return nil, fmt.Errorf("x: %w", err)
+		}
+		y, err := decodeInt(k.Y)
+		if err != nil {
+			return nil, fmt.Errorf("y: %w", err)
+		}
+
+		key := &ecdsa.PublicKey{Curve: curve, X: x, Y: y}
+		// conversion validates the point is on curve
+		if _, err := key.ECDH(); err != nil {
+			return nil, err
+		}
+
+		return key, nil
+	case "OKP":
+		if k.Curve != "Ed25519" {
+			return nil, fmt.Errorf("unsupported crv %q", k.Curve)
+		}
+		x, err := base64.RawURLEncoding.DecodeString(k.X)
+		if err != nil {
+			return nil, fmt.Errorf("x: %w", err)
+		}
+		if len(x) != ed25519.PublicKeySize {
+			return nil, errors.New("x must have 32 bytes")
+		}
+
+		return ed25519.PublicKey(x), nil
+	default:
+		return nil, fmt.Errorf("unsupported kty %q", k.KeyType)
+	}
+}
+
+func ellipticCurve(name string) (elliptic.Curve, error) {
+	switch name {
+	case "P-256":
+		return elliptic.P256(), nil
+	case "P-384":
+		return elliptic.P384(), nil
+	case "P-521":
+		return elliptic.P521(), nil
+	default:
+		return nil, fmt.Errorf("unsupported crv %q", name)
+	}
+}
+
+// decodeInt decodes the base64url big endian integers of RFC 7518
+func decodeInt(value string) (*big.Int, error) {
+	if value == "" {
+		return nil, errors.New("is required")
+	}
+
+	decoded, err := base64.RawURLEncoding.DecodeString(value)
+	if err != nil {
+		return nil, err
+	}
+
+	return new(big.Int).SetBytes(decoded), nil
+}
@@ -0,0 +1,131 @@
+package jwks_test
+
+import (
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"fmt"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/italorfeitosa/payee-account-manager-api/internal/infra/jwks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func encode(b []byte) string {
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+func TestKeySet(t *testing.T) {
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	edPublic, edKey, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	path := filepath.Join(t.TempDir(), "jwks.json")
+	require.NoError(t, os.WriteFile(path, []byte(fmt.Sprintf(`{"keys": [
+		{"kty": "RSA", "kid": "rsa", "use": "sig", "alg": "RS256", "n": "%s", "e": "%s"},
+		{"kty": "EC", "kid": "ec", "crv": "P-256", "x": "%s", "y": "%s"},
+		{"kty": "OKP", "kid": "ed", "crv": "Ed25519", "x": "%s"},
+		{"kty": "RSA", "kid": "enc", "use": "enc", "n": "", "e": ""}
+	]}`,
+		encode(rsaKey.N.Bytes()), encode(big.NewInt(int64(rsaKey.E)).Bytes()),
+		encode(ecKey.X.Bytes()), encode(ecKey.Y.Bytes()),
+		encode(edPublic),
+	)), 0o600))
+
+	keySet, err := jwks.LoadFile(path)
+	require.NoError(t, err)
+
+	sign := func(method jwt.SigningMethod, kid string, key any) string {
+		token := jwt.NewWithClaims(method, jwt.MapClaims{"sub": "italo"})
+		if kid != "" {
+			token.Header["kid"] = kid
+		}
+
+		signed, err := token.SignedString(key)
+		require.NoError(t, err)
+
+		return signed
+	}
+
+	tests := []struct {
+		name    string
+		token   string
+		wantErr bool
+	}{
+		{"given RS256 token signed by rsa key should verify it", sign(jwt.SigningMethodRS256, "rsa", rsaKey), false},
+		{"given ES256 token signed by ec key should verify it", sign(jwt.SigningMethodES256, "ec", ecKey), false},
+		{"given EdDSA token signed by ed25519 key should verify it", sign(jwt.SigningMethodEdDSA, "ed", edKey), false},
+		{"given unknown kid should return error", sign(jwt.SigningMethodRS256, "other", rsaKey), true},
+		{"given token without kid and many keys should return error", sign(jwt.SigningMethodRS256, "", rsaKey), true},
+		{"given algorithm other than key alg should return error", sign(jwt.SigningMethodPS256, "rsa", rsaKey), true},
+		{"given token signed by another key should return error", sign(jwt.SigningMethodES256, "ec", mustECKey(t)), true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := jwt.Parse(tt.token, keySet.Keyfunc)
+
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestParse(t *testing.T) {
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	t.Run("given a single key should verify tokens without kid", func(t *testing.T) {
+		keySet, err := jwks.Parse([]byte(fmt.Sprintf(`{"keys": [{"kty": "RSA", "n": "%s", "e": "AQAB"}]}`, encode(rsaKey.N.Bytes()))))
+		require.NoError(t, err)
+
+		token, err := jwt.New(jwt.SigningMethodRS256).SignedString(rsaKey)
+		require.NoError(t, err)
+
+		_, err = jwt.Parse(token, keySet.Keyfunc)
+		assert.NoError(t, err)
+	})
+
+	invalid := []struct {
+		name     string
+		document string
+	}{
+		{"given invalid json should return error", `{"keys": `},
+		{"given no signing keys should return error", `{"keys": []}`},
+		{"given unsupported key type should return error", `{"keys": [{"kty": "oct", "k": "c2VjcmV0"}]}`},
+		{"given point out of curve should return error", `{"keys": [{"kty": "EC", "crv": "P-256", "x": "AQ", "y": "AQ"}]}`},
+		{"given duplicated kid should return error", `{"keys": [{"kty": "OKP", "kid": "a", "crv": "Ed25519", "x": "` + encode(make([]byte, 32)) + `"}, {"kty": "OKP", "kid": "a", "crv": "Ed25519", "x": "` + encode(make([]byte, 32)) + `"}]}`},
+	}
+
+	for _, tt := range invalid {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := jwks.Parse([]byte(tt.document))
+
+			assert.ErrorIs(t, err, jwks.ErrInvalidKeySet)
+		})
+	}
+}
+
+func mustECKey(t *testing.T) *ecdsa.PrivateKey {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	return key
+}
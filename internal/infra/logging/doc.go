@@ -0,0 +1,3 @@
+// logging package provides a slog.Handler that masks personal data (documents, pix keys,
+// emails and phones) and tags log lines with request and trace ids
+package logging
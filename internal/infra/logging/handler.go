@@ -0,0 +1,94 @@
+package logging
+
+import (
+	"context"
+	"log/slog"
+
+	"go.opentelemetry.io/otel/trace"
+)
+
+// RedactingHandler masks personal data of messages and attributes before delegating to next handler,
+// and adds request_id and trace_id attributes from context
+type RedactingHandler struct {
+	next slog.Handler
+}
+
+var _ slog.Handler = (*RedactingHandler)(nil)
+
+func NewRedactingHandler(next slog.Handler) *RedactingHandler {
+	return &RedactingHandler{next}
+}
+
+func (h *RedactingHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	return h.next.Enabled(ctx, level)
+}
+
+func (h *RedactingHandler) Handle(ctx context.Context, record slog.Record) error {
+	redacted := slog.NewRecord(record.Time, record.Level, Redact(record.Message), record.PC)
+
+	record.Attrs(func(attr slog.Attr) bool {
+		redacted.AddAttrs(redactAttr(attr))
+		return true
+	})
+
+	if requestID := RequestID(ctx); requestID != "" {
+		redacted.AddAttrs(slog.String("request_id", requestID))
+	}
+
+	if spanContext := trace.SpanContextFromContext(ctx); spanContext.IsValid() {
+		redacted.AddAttrs(slog.String("trace_id", spanContext.TraceID().String()))
+	}
+
+	return h.next.Handle(ctx, redacted)
+}
+
+func (h *RedactingHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	redacted := make([]slog.Attr, 0, len(attrs))
+	for _, attr := range attrs {
+		redacted = append(redacted, redactAttr(attr))
+	}
+
+	return &RedactingHandler{h.next.WithAttrs(redacted)}
+}
+
+func (h *RedactingHandler) WithGroup(name string) slog.Handler {
+	return &RedactingHandler{h.next.WithGroup(name)}
+}
+
+// redactAttr masks values of sensitive keys and personal data inside any other value,
+// walking groups, and resolving LogValuers, errors and other kinds to their text
+func redactAttr(attr slog.Attr) slog.Attr {
+	return redactAttrOf(attr, false)
+}
+
+// redactAttrOf redacts attr, members of a group with sensitive key are masked as sensitive too
+func redactAttrOf(attr slog.Attr, sensitiveGroup bool) slog.Attr {
+	attr.Value = attr.Value.Resolve()
+	sensitive := sensitiveGroup || sensitiveKeys[attr.Key]
+
+	switch {
+	case attr.Value.Kind() == slog.KindGroup:
+		group := attr.Value.Group()
+		redacted := make([]any, 0, len(group))
+		for _, member := range group {
+			redacted = append(redacted, redactAttrOf(member, sensitive))
+		}
+
+		return slog.Group(attr.Key, redacted...)
+	case sensitive:
+		return slog.String(attr.Key, maskValue(attr.Value.String()))
+	case isIdentifierKey(attr.Key):
+		return attr
+	case attr.Value.Kind() == slog.KindString:
+		return slog.String(attr.Key, Redact(attr.Value.String()))
+	default:
+		// errors, structs and numbers may hold personal data in their text, they are only replaced
+		// when they do, so other values keep their kind
+		text := attr.Value.String()
+		if redacted := Redact(text); redacted != text {
+			return slog.String(attr.Key, redacted)
+		}
+
+		return attr
+	}
+}
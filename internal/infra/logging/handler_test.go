@@ -0,0 +1,162 @@
+package logging_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"testing"
+
+	"github.com/italorfeitosa/payee-account-manager-api/internal/domain"
+	"github.com/italorfeitosa/payee-account-manager-api/internal/infra/logging"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func newTestLogger() (*slog.Logger, *bytes.Buffer) {
+	var buf bytes.Buffer
+	return slog.New(logging.NewRedactingHandler(slog.NewJSONHandler(&buf, nil))), &buf
+}
+
+func decodeLine(t *testing.T, buf *bytes.Buffer) map[string]any {
+	t.Helper()
+
+	var line map[string]any
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &line))
+
+	return line
+}
+
+func TestRedactingHandler(t *testing.T) {
+	t.Run("given sensitive keys should mask values whatever their content", func(t *testing.T) {
+		logger, buf := newTestLogger()
+
+		logger.Warn("tempered pix key",
+			slog.String("pix_key", "italo@feitosa.com"),
+			slog.String("cpf_cnpj", "not a document"),
+			slog.String("email", ""),
+		)
+
+		line := decodeLine(t, buf)
+		assert.Equal(t, "i***@feitosa.com", line["pix_key"])
+		assert.Equal(t, logging.Redacted, line["cpf_cnpj"])
+		assert.Equal(t, "", line["email"])
+	})
+
+	t.Run("given personal data in message and attributes should mask it", func(t *testing.T) {
+		logger, buf := newTestLogger()
+
+		logger.Info("payee italo@feitosa.com registered",
+			slog.String("search", "99818083008"),
+			slog.Any("error", fmt.Errorf("%w: 99818083008", domain.ErrInvalidDocument)),
+			slog.Group("payee", slog.String("telefone", "+5511987654321"), slog.Int("pix_keys", 2)),
+		)
+
+		line := decodeLine(t, buf)
+		assert.Equal(t, "payee i***@feitosa.com registered", line["msg"])
+		assert.Equal(t, "***.180.830-**", line["search"])
+		assert.Equal(t, "invalid document: ***.180.830-**", line["error"])
+		assert.Equal(t, map[string]any{"telefone": "+55 (11) 9****-4321", "pix_keys": float64(2)}, line["payee"])
+	})
+
+	t.Run("given logger attributes should mask them", func(t *testing.T) {
+		logger, buf := newTestLogger()
+
+		logger.With(slog.String("pix_key", "99818083008")).Info("pix key added")
+
+		assert.Equal(t, "***.180.830-**", decodeLine(t, buf)["pix_key"])
+	})
+
+	t.Run("given values that are not personal data should keep their type", func(t *testing.T) {
+		logger, buf := newTestLogger()
+
+		logger.Info("request completed", slog.Int("status", 201), slog.Any("error", errors.New("payee not found")))
+
+		line := decodeLine(t, buf)
+		assert.Equal(t, float64(201), line["status"])
+		assert.Equal(t, "payee not found", line["error"])
+	})
+
+	t.Run("given personal data in every attribute kind should mask it", func(t *testing.T) {
+		logger, buf := newTestLogger()
+
+		logger.Info("pix key added",
+			slog.String("key", "6f1c2a4e-8b5d-4c3a-9e7f-1a2b3c4d5e6f"),
+			slog.Group("payee", slog.Group("contact", slog.String("value", "italo@feitosa.com"))),
+			slog.Any("payload", map[string]string{"document": "99818083008"}),
+			slog.Any("owner", payeeValuer{"italo@feitosa.com"}),
+			slog.Any("error", fmt.Errorf("duplicated: %w", errors.New("pix key 6f1c2a4e-8b5d-4c3a-9e7f-1a2b3c4d5e6f"))),
+			slog.Int64("cpf", 99818083008),
+			slog.Group("pix_key", slog.String("value", "not a pix key")),
+		)
+
+		line := decodeLine(t, buf)
+		assert.Equal(t, "6f1c2a4e-****-****-****-********5e6f", line["key"])
+		assert.Equal(t, map[string]any{"contact": map[string]any{"value": "i***@feitosa.com"}}, line["payee"])
+		assert.Equal(t, "map[document:***.180.830-**]", line["payload"])
+		assert.Equal(t, map[string]any{"email": "i***@feitosa.com"}, line["owner"])
+		assert.Equal(t, "duplicated: pix key 6f1c2a4e-****-****-****-********5e6f", line["error"])
+		assert.Equal(t, "***.180.830-**", line["cpf"])
+		assert.Equal(t, map[string]any{"value": logging.Redacted}, line["pix_key"])
+	})
+
+	t.Run("given identifier attributes should keep them", func(t *testing.T) {
+		logger, buf := newTestLogger()
+
+		logger.Info("payee saved", slog.String("payee_id", "6f1c2a4e-8b5d-4c3a-9e7f-1a2b3c4d5e6f"))
+
+		assert.Equal(t, "6f1c2a4e-8b5d-4c3a-9e7f-1a2b3c4d5e6f", decodeLine(t, buf)["payee_id"])
+	})
+
+	t.Run("given request id and span in context should add them", func(t *testing.T) {
+		logger, buf := newTestLogger()
+
+		traceID, _ := trace.TraceIDFromHex("4bf92f3577b34da6a3ce929d0e0e4736")
+		spanID, _ := trace.SpanIDFromHex("00f067aa0ba902b7")
+		ctx := trace.ContextWithSpanContext(context.Background(), trace.NewSpanContext(trace.SpanContextConfig{
+			TraceID: traceID,
+			SpanID:  spanID,
+		}))
+		ctx = logging.WithRequestID(ctx, "req-123")
+
+		logger.InfoContext(ctx, "request completed")
+
+		line := decodeLine(t, buf)
+		assert.Equal(t, "req-123", line["request_id"])
+		assert.Equal(t, "4bf92f3577b34da6a3ce929d0e0e4736", line["trace_id"])
+	})
+
+	t.Run("given a tempered value restored in a request should warn with request id and redacted value", func(t *testing.T) {
+		logger, buf := newTestLogger()
+		defaultLogger := slog.Default()
+		slog.SetDefault(logger)
+		t.Cleanup(func() { slog.SetDefault(defaultLogger) })
+
+		domain.RestorePixKey(logging.WithRequestID(context.Background(), "req-123"), domain.CPFPixKeyType, "123456789")
+
+		line := decodeLine(t, buf)
+		assert.Equal(t, "WARN", line["level"])
+		assert.Equal(t, "req-123", line["request_id"])
+		assert.Equal(t, "[REDACTED]", line["pix_key"])
+	})
+
+	t.Run("given context without request should not add ids", func(t *testing.T) {
+		logger, buf := newTestLogger()
+
+		logger.InfoContext(context.Background(), "started")
+
+		line := decodeLine(t, buf)
+		assert.NotContains(t, line, "request_id")
+		assert.NotContains(t, line, "trace_id")
+	})
+}
+
+// payeeValuer logs itself as a group, as domain values implementing slog.LogValuer would do
+type payeeValuer struct{ email string }
+
+func (p payeeValuer) LogValue() slog.Value {
+	return slog.GroupValue(slog.String("email", p.email))
+}
@@ -0,0 +1,93 @@
+package logging
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/italorfeitosa/payee-account-manager-api/internal/domain"
+)
+
+// Redacted replaces sensitive values that cannot be masked by their domain format
+const Redacted = "[REDACTED]"
+
+// sensitiveKeys are attribute keys whose values are always masked, whatever their content
+var sensitiveKeys = map[string]bool{
+	"pix_key":  true,
+	"cpf_cnpj": true,
+	"document": true,
+	"email":    true,
+	"phone":    true,
+	"telefone": true,
+}
+
+// isIdentifierKey reports if attribute key holds an id (Ex: payee_id), kept as is, as random
+// pix keys and ids are both uuids, and ids are needed to trace what happened
+func isIdentifierKey(key string) bool {
+	return key == "id" || strings.HasSuffix(key, "_id")
+}
+
+// tokenRegex splits text in candidate values, delimited by spaces, quotes, brackets and separators,
+// colons included, as fmt prints maps and structs as key:value
+var tokenRegex = regexp.MustCompile(`[^\s"'()\[\]{}<>,;:=&?]+`)
+
+// Redact masks every document, email, phone and random pix key of text, matching each token against domain regexes
+// (Ex: "payee 99818083008 created" returns "payee ***.180.830-** created")
+func Redact(text string) string {
+	return tokenRegex.ReplaceAllStringFunc(text, func(token string) string {
+		if masked, ok := maskToken(token); ok {
+			return masked
+		}
+
+		// token may end a sentence (Ex: "cpf 99818083008.")
+		trimmed := strings.TrimRight(token, ".")
+		if masked, ok := maskToken(trimmed); ok && trimmed != token {
+			return masked + token[len(trimmed):]
+		}
+
+		return token
+	})
+}
+
+// maskToken returns the masked token when it is a document, email, phone or random pix key
+func maskToken(token string) (string, bool) {
+	switch {
+	case domain.ChaveAleatoriaRegex.MatchString(token):
+		key, _ := domain.NewChaveAleatoriaPixKey(token)
+		return key.Masked(), true
+	case domain.CPFRegex.MatchString(token), domain.CNPJRegex.MatchString(token):
+		if document, err := domain.NewDocument(token); err == nil {
+			return document.Masked(), true
+		}
+
+		return Redacted, true
+	case domain.EmailRegex.MatchString(strings.ToLower(token)):
+		if email, err := domain.NewEmail(token); err == nil {
+			return email.Masked(), true
+		}
+
+		return Redacted, true
+	case domain.TelefoneRegex.MatchString(token):
+		if telefone, err := domain.NewPixKey(domain.TelefonePixKeyType, token); err == nil {
+			return telefone.Masked(), true
+		}
+
+		return Redacted, true
+	case domain.PhoneRegex.MatchString(token):
+		return Redacted, true
+	}
+
+	return token, false
+}
+
+// maskValue masks a value of sensitive key, keeping the domain masked format when value is valid
+func maskValue(value string) string {
+	if masked, ok := maskToken(value); ok {
+		return masked
+	}
+
+	if value == "" {
+		return value
+	}
+
+	return Redacted
+}
@@ -0,0 +1,34 @@
+package logging_test
+
+import (
+	"testing"
+
+	"github.com/italorfeitosa/payee-account-manager-api/internal/infra/logging"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRedact(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+		want string
+	}{
+		{"given text without personal data should keep it", "payee saved in 12ms", "payee saved in 12ms"},
+		{"given random pix key should mask it", "pix key 6f1c2a4e-8b5d-4c3a-9e7f-1a2b3c4d5e6f added", "pix key 6f1c2a4e-****-****-****-********5e6f added"},
+		{"given cpf should mask it", "invalid document: 99818083008", "invalid document: ***.180.830-**"},
+		{"given formatted cpf should mask it", "cpf 998.180.830-08 registered", "cpf ***.180.830-** registered"},
+		{"given cpf with invalid digits should redact it", "document 12345678900", "document " + logging.Redacted},
+		{"given labeled cpf should mask it", "cpf:99818083008", "cpf:***.180.830-**"},
+		{"given cnpj should mask it", "cnpj=19039318000104", "cnpj=**.039.318/0001-**"},
+		{"given email should mask it", "sent to italo@feitosa.com.", "sent to i***@feitosa.com."},
+		{"given telefone should mask it", "pix key +5511987654321 removed", "pix key +55 (11) 9****-4321 removed"},
+		{"given landline phone should redact it", "call 1133334444", "call " + logging.Redacted},
+		{"given quoted values should mask them", `keys ["italo@feitosa.com","99818083008"]`, `keys ["i***@feitosa.com","***.180.830-**"]`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, logging.Redact(tt.text))
+		})
+	}
+}
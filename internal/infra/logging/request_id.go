@@ -0,0 +1,18 @@
+package logging
+
+import (
+	"context"
+)
+
+type requestIDContextKey struct{}
+
+// WithRequestID returns ctx carrying the request id, which is added to every log line written with ctx
+func WithRequestID(ctx context.Context, requestID string) context.Context {
+	return context.WithValue(ctx, requestIDContextKey{}, requestID)
+}
+
+// RequestID returns the request id of ctx, empty when ctx has none
+func RequestID(ctx context.Context) string {
+	requestID, _ := ctx.Value(requestIDContextKey{}).(string)
+	return requestID
+}
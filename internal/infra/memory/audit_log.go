@@ -0,0 +1,45 @@
+package memory
+
+import (
+	"context"
+	"sync"
+
+	"github.com/italorfeitosa/payee-account-manager-api/internal/application"
+)
+
+// AuditLog keeps audit entries in memory, by tenant and payee
+type AuditLog struct {
+	mu      sync.RWMutex
+	entries map[string]map[string][]application.AuditEntry
+}
+
+var _ application.AuditLog = (*AuditLog)(nil)
+
+func NewAuditLog() *AuditLog {
+	return &AuditLog{entries: make(map[string]map[string][]application.AuditEntry)}
+}
+
+func (l *AuditLog) Record(_ context.Context, entry application.AuditEntry) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	tenantEntries, ok := l.entries[entry.TenantID]
+	if !ok {
+		tenantEntries = make(map[string][]application.AuditEntry)
+		l.entries[entry.TenantID] = tenantEntries
+	}
+
+	tenantEntries[entry.PayeeID] = append(tenantEntries[entry.PayeeID], entry)
+
+	return nil
+}
+
+func (l *AuditLog) List(_ context.Context, tenantID string, payeeID string) ([]application.AuditEntry, error) {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+
+	entries := l.entries[tenantID][payeeID]
+
+	// copied, so callers can't change the trail
+	return append([]application.AuditEntry(nil), entries...), nil
+}
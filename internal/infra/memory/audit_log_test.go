@@ -0,0 +1,41 @@
+package memory_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/italorfeitosa/payee-account-manager-api/internal/application"
+	"github.com/italorfeitosa/payee-account-manager-api/internal/infra/memory"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAuditLog(t *testing.T) {
+	ctx := context.Background()
+	tenantID := uuid.NewString()
+	payeeID := uuid.NewString()
+	now := time.Now().UTC()
+
+	log := memory.NewAuditLog()
+	registered := application.AuditEntry{TenantID: tenantID, PayeeID: payeeID, Actor: "italo", Action: application.PayeeRegisteredAction, OccurredAt: now}
+	validated := application.AuditEntry{TenantID: tenantID, PayeeID: payeeID, Actor: "feitosa", Action: application.PayeeValidatedAction, OccurredAt: now}
+	require.NoError(t, log.Record(ctx, registered))
+	require.NoError(t, log.Record(ctx, validated))
+	require.NoError(t, log.Record(ctx, application.AuditEntry{TenantID: tenantID, PayeeID: uuid.NewString(), Action: application.PayeeDeletedAction}))
+
+	t.Run("given a payee with entries should list them in record order", func(t *testing.T) {
+		got, err := log.List(ctx, tenantID, payeeID)
+
+		require.NoError(t, err)
+		assert.Equal(t, []application.AuditEntry{registered, validated}, got)
+	})
+
+	t.Run("given payee of another tenant should return no entries", func(t *testing.T) {
+		got, err := log.List(ctx, uuid.NewString(), payeeID)
+
+		require.NoError(t, err)
+		assert.Empty(t, got)
+	})
+}
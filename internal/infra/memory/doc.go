@@ -0,0 +1,3 @@
+// memory package implements application ports storing data in process memory,
+// useful for local runs and tests
+package memory
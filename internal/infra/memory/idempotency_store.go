@@ -0,0 +1,99 @@
+package memory
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/italorfeitosa/payee-account-manager-api/internal/application"
+)
+
+type idempotencyEntry struct {
+	record    application.IdempotencyRecord
+	expiresAt time.Time
+}
+
+// IdempotencyStore keeps completed idempotency keys in memory until ttl since their completion.
+// A reservation in progress only lasts for lease, so a key reserved by a process that died before
+// completing or releasing it can be taken over by a retry
+type IdempotencyStore struct {
+	ttl     time.Duration
+	lease   time.Duration
+	mu      sync.Mutex
+	entries map[string]map[string]*idempotencyEntry
+}
+
+var _ application.IdempotencyStore = (*IdempotencyStore)(nil)
+
+func NewIdempotencyStore(ttl, lease time.Duration) *IdempotencyStore {
+	return &IdempotencyStore{ttl: ttl, lease: lease, entries: make(map[string]map[string]*idempotencyEntry)}
+}
+
+func (s *IdempotencyStore) Reserve(_ context.Context, tenantID, key, fingerprint string) (application.IdempotencyRecord, bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+
+	tenantEntries, ok := s.entries[tenantID]
+	if !ok {
+		tenantEntries = make(map[string]*idempotencyEntry)
+		s.entries[tenantID] = tenantEntries
+	}
+
+	// an expired key or a stale reservation is reserved again, DeleteExpired frees the memory of the others
+	if entry, ok := tenantEntries[key]; ok && now.Before(entry.expiresAt) {
+		return entry.record, false, nil
+	}
+
+	record := application.IdempotencyRecord{Fingerprint: fingerprint}
+	tenantEntries[key] = &idempotencyEntry{record: record, expiresAt: now.Add(s.lease)}
+
+	return record, true, nil
+}
+
+func (s *IdempotencyStore) Complete(_ context.Context, tenantID, key, payeeID string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if entry, ok := s.entries[tenantID][key]; ok {
+		entry.record.PayeeID = payeeID
+		entry.expiresAt = time.Now().Add(s.ttl)
+	}
+
+	return nil
+}
+
+func (s *IdempotencyStore) Release(_ context.Context, tenantID, key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.entries[tenantID], key)
+
+	return nil
+}
+
+// DeleteExpired drops expired keys of all tenants, as a database would do with a ttl index,
+// and returns how many keys were dropped. It is meant to run periodically, out of requests
+func (s *IdempotencyStore) DeleteExpired() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+	deleted := 0
+
+	for tenantID, tenantEntries := range s.entries {
+		for key, entry := range tenantEntries {
+			if !now.Before(entry.expiresAt) {
+				delete(tenantEntries, key)
+				deleted++
+			}
+		}
+
+		if len(tenantEntries) == 0 {
+			delete(s.entries, tenantID)
+		}
+	}
+
+	return deleted
+}
@@ -0,0 +1,127 @@
+package memory_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/italorfeitosa/payee-account-manager-api/internal/application"
+	"github.com/italorfeitosa/payee-account-manager-api/internal/infra/memory"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIdempotencyStore(t *testing.T) {
+	ctx := context.Background()
+	tenantID := uuid.NewString()
+
+	t.Run("given a new key should reserve it", func(t *testing.T) {
+		store := memory.NewIdempotencyStore(time.Hour, time.Minute)
+
+		record, reserved, err := store.Reserve(ctx, tenantID, "key", "fingerprint")
+
+		require.NoError(t, err)
+		assert.True(t, reserved)
+		assert.Equal(t, application.IdempotencyRecord{Fingerprint: "fingerprint"}, record)
+	})
+
+	t.Run("given a completed key should return stored record", func(t *testing.T) {
+		store := memory.NewIdempotencyStore(time.Hour, time.Minute)
+		_, _, err := store.Reserve(ctx, tenantID, "key", "fingerprint")
+		require.NoError(t, err)
+		require.NoError(t, store.Complete(ctx, tenantID, "key", "payee-id"))
+
+		record, reserved, err := store.Reserve(ctx, tenantID, "key", "other")
+
+		require.NoError(t, err)
+		assert.False(t, reserved)
+		assert.Equal(t, application.IdempotencyRecord{Fingerprint: "fingerprint", PayeeID: "payee-id"}, record)
+	})
+
+	t.Run("given a released key should reserve it again", func(t *testing.T) {
+		store := memory.NewIdempotencyStore(time.Hour, time.Minute)
+		_, _, err := store.Reserve(ctx, tenantID, "key", "fingerprint")
+		require.NoError(t, err)
+		require.NoError(t, store.Release(ctx, tenantID, "key"))
+
+		_, reserved, err := store.Reserve(ctx, tenantID, "key", "other")
+
+		require.NoError(t, err)
+		assert.True(t, reserved)
+	})
+
+	t.Run("given an expired key should reserve it again", func(t *testing.T) {
+		store := memory.NewIdempotencyStore(time.Millisecond, time.Millisecond)
+		_, _, err := store.Reserve(ctx, tenantID, "key", "fingerprint")
+		require.NoError(t, err)
+		require.NoError(t, store.Complete(ctx, tenantID, "key", "payee-id"))
+
+		time.Sleep(2 * time.Millisecond)
+
+		record, reserved, err := store.Reserve(ctx, tenantID, "key", "other")
+
+		require.NoError(t, err)
+		assert.True(t, reserved)
+		assert.Equal(t, "other", record.Fingerprint)
+	})
+
+	t.Run("given a reservation not completed within lease should let a retry take it over", func(t *testing.T) {
+		store := memory.NewIdempotencyStore(time.Hour, time.Millisecond)
+		_, _, err := store.Reserve(ctx, tenantID, "key", "fingerprint")
+		require.NoError(t, err)
+
+		time.Sleep(2 * time.Millisecond)
+
+		record, reserved, err := store.Reserve(ctx, tenantID, "key", "fingerprint")
+
+		require.NoError(t, err)
+		assert.True(t, reserved)
+		assert.Equal(t, application.IdempotencyRecord{Fingerprint: "fingerprint"}, record)
+	})
+
+	t.Run("given a key completed within lease should keep it after lease until ttl", func(t *testing.T) {
+		store := memory.NewIdempotencyStore(time.Hour, time.Millisecond)
+		_, _, err := store.Reserve(ctx, tenantID, "key", "fingerprint")
+		require.NoError(t, err)
+		require.NoError(t, store.Complete(ctx, tenantID, "key", "payee-id"))
+
+		time.Sleep(2 * time.Millisecond)
+
+		record, reserved, err := store.Reserve(ctx, tenantID, "key", "fingerprint")
+
+		require.NoError(t, err)
+		assert.False(t, reserved)
+		assert.Equal(t, application.IdempotencyRecord{Fingerprint: "fingerprint", PayeeID: "payee-id"}, record)
+	})
+}
+
+func TestIdempotencyStore_DeleteExpired(t *testing.T) {
+	ctx := context.Background()
+	tenantID := uuid.NewString()
+
+	t.Run("given expired keys should delete only them", func(t *testing.T) {
+		store := memory.NewIdempotencyStore(time.Millisecond, time.Millisecond)
+		_, _, err := store.Reserve(ctx, tenantID, "expired", "fingerprint")
+		require.NoError(t, err)
+		_, _, err = store.Reserve(ctx, uuid.NewString(), "expired", "fingerprint")
+		require.NoError(t, err)
+
+		time.Sleep(2 * time.Millisecond)
+
+		assert.Equal(t, 2, store.DeleteExpired())
+		assert.Equal(t, 0, store.DeleteExpired())
+	})
+
+	t.Run("given keys within ttl should keep them", func(t *testing.T) {
+		store := memory.NewIdempotencyStore(time.Hour, time.Minute)
+		_, _, err := store.Reserve(ctx, tenantID, "key", "fingerprint")
+		require.NoError(t, err)
+
+		assert.Equal(t, 0, store.DeleteExpired())
+
+		_, reserved, err := store.Reserve(ctx, tenantID, "key", "other")
+		require.NoError(t, err)
+		assert.False(t, reserved)
+	})
+}
@@ -0,0 +1,39 @@
+package memory
+
+import (
+	"context"
+	"slices"
+	"sync"
+
+	"github.com/italorfeitosa/payee-account-manager-api/internal/application"
+)
+
+// Mailer is an in memory implementation of application.Mailer, it keeps sent messages
+// instead of delivering them, for tests
+type Mailer struct {
+	mu       sync.RWMutex
+	messages []application.EmailMessage
+}
+
+func NewMailer() *Mailer {
+	return &Mailer{}
+}
+
+var _ application.Mailer = (*Mailer)(nil)
+
+func (m *Mailer) Send(_ context.Context, message application.EmailMessage) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.messages = append(m.messages, message)
+
+	return nil
+}
+
+// Messages returns sent messages in sending order
+func (m *Mailer) Messages() []application.EmailMessage {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	return slices.Clone(m.messages)
+}
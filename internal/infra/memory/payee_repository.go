@@ -0,0 +1,758 @@
+package memory
+
+import (
+	"context"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/italorfeitosa/payee-account-manager-api/internal/application"
+	"github.com/italorfeitosa/payee-account-manager-api/internal/domain"
+	"github.com/italorfeitosa/payee-account-manager-api/internal/infra/encryption"
+)
+
+// Blind index fields, part of the index so equal values of different fields don't match
+const (
+	documentIndexField      = "cpf_cnpj"
+	cnpjRootIndexField      = "cnpj_root"
+	pixKeyIndexField        = "pix_key"
+	accountNumberIndexField = "account_number"
+)
+
+// cnpjRootLength is the size of CNPJ root, the company part of CNPJ without branch order and check digits
+const cnpjRootLength = 8
+
+// payeeRecord is the persisted representation of a payee, as a database row would be
+type payeeRecord struct {
+	// Sequence keeps insertion order, as an auto increment column
+	Sequence int64
+	TenantID string
+	ID       string
+	Name     string
+	// TradeName is empty for NATURAL payees
+	TradeName string
+	// NameSearchKey and TradeNameSearchKey are normalized names, as indexed columns would be
+	NameSearchKey      string
+	TradeNameSearchKey string
+	// InscricaoEstadual is nil when payee has no state registration
+	InscricaoEstadual *inscricaoEstadualRecord
+	// DataKey encrypts Document, pix key values and bank account number of record
+	DataKey encryption.WrappedKey
+	// Document is encrypted, DocumentIndex is its blind index, as an indexed column for exact match
+	Document      []byte
+	DocumentIndex string
+	// CNPJRootIndex is the blind index of the 8 characters CNPJ root, empty for CPF
+	CNPJRootIndex string
+	// DocumentType is stored as a discriminator column, to filter by person kind
+	DocumentType string
+	Status       string
+	Email        string
+	// EmailVerifiedAt is nil while email is not verified
+	EmailVerifiedAt *time.Time
+	Address         *addressRecord
+	Contacts        []contactRecord
+	PixKeys         []pixKeyRecord
+	BankAccount     *bankAccountRecord
+	CreatedAt       time.Time
+	UpdatedAt       time.Time
+	DeletedAt       *time.Time
+	// AnonymizedAt is nil while personal data is kept, anonymized records have empty blind indexes
+	AnonymizedAt *time.Time
+}
+
+// inscricaoEstadualRecord is the persisted state registration of a payee, as embedded columns would be
+type inscricaoEstadualRecord struct {
+	UF    string
+	Value string
+}
+
+// addressRecord is the persisted address of a payee, as embedded columns would be
+type addressRecord struct {
+	Street       string
+	Number       string
+	Complement   string
+	Neighborhood string
+	City         string
+	UF           string
+	CEP          string
+}
+
+// contactRecord is the persisted contact of a payee, as a child table row would be
+type contactRecord struct {
+	Type    string
+	Value   string
+	Primary bool
+}
+
+// pixKeyRecord is the persisted pix key of a payee, as a child table row would be
+type pixKeyRecord struct {
+	Type string
+	// Value is encrypted, ValueIndex is its blind index
+	Value      []byte
+	ValueIndex string
+	Primary    bool
+}
+
+// bankAccountRecord is the persisted bank account of a payee, as embedded columns would be
+type bankAccountRecord struct {
+	AccountType string
+	// AccountNumber is encrypted, AccountNumberIndex is its blind index
+	AccountNumber      []byte
+	AccountNumberIndex string
+	AccountDigit       string
+	BranchNumber       string
+	BankCode           string
+	BankIspb           string
+}
+
+// fieldAssociatedData binds an encrypted field to its record, so it can't be decrypted in another one
+func fieldAssociatedData(tenantID, payeeID, field string) string {
+	return tenantID + "/" + payeeID + "/" + field
+}
+
+// newPayeeRecord encrypts payee document, pix keys and bank account number with a new data key
+func newPayeeRecord(ctx context.Context, fields *encryption.FieldEncrypter, tenantID string, payee *domain.PayeeEntity) (*payeeRecord, error) {
+	dataKey, err := fields.NewDataKey(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	record := &payeeRecord{
+		TenantID:           tenantID,
+		ID:                 payee.ID(),
+		Name:               payee.Name(),
+		TradeName:          payee.TradeName(),
+		NameSearchKey:      payee.NameSearchKey(),
+		TradeNameSearchKey: payee.TradeNameSearchKey(),
+		DataKey:            dataKey.Wrapped(),
+		DocumentType:       string(payee.DocumentType()),
+		Status:             payee.Status().Value(),
+		Email:              payee.Email(),
+		EmailVerifiedAt:    payee.EmailVerifiedAt(),
+		CreatedAt:          payee.CreatedAt(),
+		UpdatedAt:          payee.UpdatedAt(),
+		AnonymizedAt:       payee.AnonymizedAt(),
+	}
+
+	// encrypt returns value encrypted and its blind index
+	encrypt := func(field, value string) ([]byte, string, error) {
+		ciphertext, err := dataKey.Encrypt(value, fieldAssociatedData(tenantID, payee.ID(), field))
+		if err != nil {
+			return nil, "", err
+		}
+
+		index, err := fields.BlindIndex(ctx, tenantID, field, value)
+		if err != nil {
+			return nil, "", err
+		}
+
+		return ciphertext, index, nil
+	}
+
+	document := payee.Document().Value()
+	if record.Document, record.DocumentIndex, err = encrypt(documentIndexField, document); err != nil {
+		return nil, err
+	}
+
+	if payee.DocumentType() == domain.CNPJDocumentType && len(document) >= cnpjRootLength {
+		if record.CNPJRootIndex, err = fields.BlindIndex(ctx, tenantID, cnpjRootIndexField, document[:cnpjRootLength]); err != nil {
+			return nil, err
+		}
+	}
+
+	if ie := payee.InscricaoEstadual(); ie != nil {
+		record.InscricaoEstadual = &inscricaoEstadualRecord{UF: ie.UF().String(), Value: ie.Value()}
+	}
+
+	if address := payee.Address(); address != nil {
+		record.Address = &addressRecord{
+			Street:       address.Street(),
+			Number:       address.Number(),
+			Complement:   address.Complement(),
+			Neighborhood: address.Neighborhood(),
+			City:         address.City(),
+			UF:           address.UF().String(),
+			CEP:          address.CEP().Value(),
+		}
+	}
+
+	for _, contact := range payee.Contacts() {
+		record.Contacts = append(record.Contacts, contactRecord{
+			Type:    string(contact.Type()),
+			Value:   contact.Value(),
+			Primary: contact.Primary(),
+		})
+	}
+
+	for i, key := range payee.PixKeys() {
+		value, index, err := encrypt(pixKeyIndexField, key.Value())
+		if err != nil {
+			return nil, err
+		}
+
+		record.PixKeys = append(record.PixKeys, pixKeyRecord{
+			Type:       key.Type(),
+			Value:      value,
+			ValueIndex: index,
+			Primary:    i == 0,
+		})
+	}
+
+	if bankAccount := payee.BankAccount(); bankAccount != nil {
+		accountNumber, index, err := encrypt(accountNumberIndexField, bankAccount.AccountNumber)
+		if err != nil {
+			return nil, err
+		}
+
+		record.BankAccount = &bankAccountRecord{
+			AccountType:        bankAccount.AccountType,
+			AccountNumber:      accountNumber,
+			AccountNumberIndex: index,
+			AccountDigit:       bankAccount.AccountDigit,
+			BranchNumber:       bankAccount.BranchNumber,
+			BankCode:           bankAccount.BankCode,
+			BankIspb:           bankAccount.BankIspb,
+		}
+	}
+
+	return record, nil
+}
+
+// restore decrypts record fields and restores the payee
+func (r *payeeRecord) restore(ctx context.Context, fields *encryption.FieldEncrypter) (*domain.PayeeEntity, error) {
+	dataKey, err := fields.OpenDataKey(ctx, r.DataKey)
+	if err != nil {
+		return nil, err
+	}
+
+	decrypt := func(field string, ciphertext []byte) (string, error) {
+		return dataKey.Decrypt(ciphertext, fieldAssociatedData(r.TenantID, r.ID, field))
+	}
+
+	document, err := decrypt(documentIndexField, r.Document)
+	if err != nil {
+		return nil, err
+	}
+
+	var bankAccount *domain.BankAccount
+	if r.BankAccount != nil {
+		accountNumber, err := decrypt(accountNumberIndexField, r.BankAccount.AccountNumber)
+		if err != nil {
+			return nil, err
+		}
+
+		bankAccount = &domain.BankAccount{
+			AccountType:   r.BankAccount.AccountType,
+			AccountNumber: accountNumber,
+			AccountDigit:  r.BankAccount.AccountDigit,
+			BranchNumber:  r.BankAccount.BranchNumber,
+			BankCode:      r.BankAccount.BankCode,
+			BankIspb:      r.BankAccount.BankIspb,
+		}
+	}
+
+	var primaryType, primaryValue string
+	opts := []domain.RestoreOption{
+		domain.RestoreTimestamps(r.CreatedAt, r.UpdatedAt),
+		domain.RestoreTradeName(r.TradeName),
+		domain.RestoreEmailVerifiedAt(r.EmailVerifiedAt),
+		domain.RestoreAnonymizedAt(r.AnonymizedAt),
+	}
+
+	if r.InscricaoEstadual != nil {
+		opts = append(opts, domain.RestoreInscricaoEstadual(r.InscricaoEstadual.UF, r.InscricaoEstadual.Value))
+	}
+
+	if r.Address != nil {
+		opts = append(opts, domain.RestoreAddress(
+			r.Address.Street,
+			r.Address.Number,
+			r.Address.Complement,
+			r.Address.Neighborhood,
+			r.Address.City,
+			r.Address.UF,
+			r.Address.CEP,
+		))
+	}
+
+	for _, contact := range r.Contacts {
+		opts = append(opts, domain.RestoreContact(contact.Type, contact.Value, contact.Primary))
+	}
+
+	for _, key := range r.PixKeys {
+		value, err := decrypt(pixKeyIndexField, key.Value)
+		if err != nil {
+			return nil, err
+		}
+
+		if key.Primary {
+			primaryType, primaryValue = key.Type, value
+			continue
+		}
+
+		opts = append(opts, domain.RestoreAdditionalPixKey(key.Type, value))
+	}
+
+	return domain.RestorePayee(
+		ctx,
+		r.ID,
+		r.Name,
+		document,
+		r.Status,
+		r.Email,
+		primaryType,
+		primaryValue,
+		bankAccount,
+		opts...,
+	), nil
+}
+
+// searchIndexes are the blind indexes of search term, to match encrypted fields. Documents and pix keys
+// are indexed in their stored format, so a term may have a few candidates (Ex: 11 digits are CPF and TELEFONE)
+type searchIndexes struct {
+	documents     []string
+	pixKeys       []string
+	accountNumber string
+}
+
+// searchPixKeyTypes are the pix key types a search term is normalized as
+var searchPixKeyTypes = []string{
+	domain.CPFPixKeyType,
+	domain.CNPJPixKeyType,
+	domain.TelefonePixKeyType,
+	domain.EmailPixKeyType,
+	domain.ChaveAleatoriaPixKeyType,
+}
+
+func newSearchIndexes(ctx context.Context, fields *encryption.FieldEncrypter, tenantID, search string) (searchIndexes, error) {
+	if search == "" {
+		return searchIndexes{}, nil
+	}
+
+	documents := []string{search}
+	if document, err := domain.NewDocument(search); err == nil {
+		documents = append(documents, document.Value())
+	}
+
+	pixKeys := []string{search}
+	for _, typ := range searchPixKeyTypes {
+		if key, err := domain.NewPixKey(typ, search); err == nil {
+			pixKeys = append(pixKeys, key.Value())
+		}
+	}
+
+	var indexes searchIndexes
+	var err error
+
+	if indexes.documents, err = blindIndexes(ctx, fields, tenantID, documentIndexField, documents); err != nil {
+		return searchIndexes{}, err
+	}
+	if indexes.pixKeys, err = blindIndexes(ctx, fields, tenantID, pixKeyIndexField, pixKeys); err != nil {
+		return searchIndexes{}, err
+	}
+	if indexes.accountNumber, err = fields.BlindIndex(ctx, tenantID, accountNumberIndexField, search); err != nil {
+		return searchIndexes{}, err
+	}
+
+	return indexes, nil
+}
+
+// blindIndexes returns the blind index of each distinct value of field
+func blindIndexes(ctx context.Context, fields *encryption.FieldEncrypter, tenantID, field string, values []string) ([]string, error) {
+	slices.Sort(values)
+
+	indexes := make([]string, 0, len(values))
+	for _, value := range slices.Compact(values) {
+		index, err := fields.BlindIndex(ctx, tenantID, field, value)
+		if err != nil {
+			return nil, err
+		}
+
+		indexes = append(indexes, index)
+	}
+
+	return indexes, nil
+}
+
+// matchRank is the quality of a search match, lower ranks are listed first
+type matchRank int
+
+const (
+	exactNameMatch matchRank = iota
+	prefixNameMatch
+	containsNameMatch
+	// fieldMatch is a match by any field other than name and trade name
+	fieldMatch
+)
+
+// match reports if record matches search term by name, trade name, cpf_cnpj, inscricao_estadual,
+// branch_number, account_number, status, pix_key_type or pix_key, and how good the match is.
+// Names are compared by search key, ignoring case and diacritics, and encrypted fields by blind index
+func (r *payeeRecord) match(search string, indexes searchIndexes) (matchRank, bool) {
+	if search == "" {
+		return exactNameMatch, true
+	}
+
+	searchKey := domain.NameSearchKey(search)
+	rank, ok := matchName(r.NameSearchKey, searchKey)
+	if tradeNameRank, tradeNameOk := matchName(r.TradeNameSearchKey, searchKey); tradeNameOk && (!ok || tradeNameRank < rank) {
+		rank, ok = tradeNameRank, true
+	}
+
+	if ok {
+		return rank, true
+	}
+
+	if strings.EqualFold(r.Status, search) || slices.Contains(indexes.documents, r.DocumentIndex) {
+		return fieldMatch, true
+	}
+
+	for _, key := range r.PixKeys {
+		if strings.EqualFold(key.Type, search) || slices.Contains(indexes.pixKeys, key.ValueIndex) {
+			return fieldMatch, true
+		}
+	}
+
+	if r.InscricaoEstadual != nil && r.InscricaoEstadual.Value == domain.NormalizeInscricaoEstadual(search) {
+		return fieldMatch, true
+	}
+
+	if r.BankAccount != nil && (r.BankAccount.BranchNumber == search || r.BankAccount.AccountNumberIndex == indexes.accountNumber) {
+		return fieldMatch, true
+	}
+
+	return 0, false
+}
+
+func matchName(nameKey, searchKey string) (matchRank, bool) {
+	switch {
+	case nameKey == "" || searchKey == "":
+		return 0, false
+	case nameKey == searchKey:
+		return exactNameMatch, true
+	case strings.HasPrefix(nameKey, searchKey):
+		return prefixNameMatch, true
+	case strings.Contains(nameKey, searchKey):
+		return containsNameMatch, true
+	default:
+		return 0, false
+	}
+}
+
+// PayeeRepository is an in memory implementation of application.PayeeRepository, which stores
+// documents, pix keys and bank account numbers encrypted, as a database would store them
+type PayeeRepository struct {
+	fields   *encryption.FieldEncrypter
+	mu       sync.RWMutex
+	sequence int64
+	records  map[string]map[string]*payeeRecord
+}
+
+func NewPayeeRepository(fields *encryption.FieldEncrypter) *PayeeRepository {
+	return &PayeeRepository{fields: fields, records: make(map[string]map[string]*payeeRecord)}
+}
+
+var _ application.PayeeRepository = (*PayeeRepository)(nil)
+
+// Ping always succeeds, as data is in process memory
+func (r *PayeeRepository) Ping(_ context.Context) error {
+	return nil
+}
+
+func (r *PayeeRepository) Save(ctx context.Context, tenantID string, payee *domain.PayeeEntity) error {
+	return r.save(ctx, tenantID, payee, nil)
+}
+
+func (r *PayeeRepository) SaveWithinQuota(ctx context.Context, tenantID string, payee *domain.PayeeEntity, since time.Time, limit int) error {
+	return r.save(ctx, tenantID, payee, func(tenantRecords map[string]*payeeRecord) error {
+		if countCreatedSince(tenantRecords, since) >= limit {
+			return application.ErrRegistrationQuotaExceeded
+		}
+
+		return nil
+	})
+}
+
+// save stores payee, check runs under the same lock of insert, as a database transaction would
+func (r *PayeeRepository) save(
+	ctx context.Context,
+	tenantID string,
+	payee *domain.PayeeEntity,
+	check func(tenantRecords map[string]*payeeRecord) error,
+) error {
+	// encrypted before locking, as key providers may be remote
+	record, err := newPayeeRecord(ctx, r.fields, tenantID, payee)
+	if err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	tenantRecords, ok := r.records[tenantID]
+	if !ok {
+		tenantRecords = make(map[string]*payeeRecord)
+		r.records[tenantID] = tenantRecords
+	}
+
+	if check != nil {
+		if err := check(tenantRecords); err != nil {
+			return err
+		}
+	}
+
+	if err := checkUniqueness(tenantRecords, record, payee); err != nil {
+		return err
+	}
+
+	if existing, ok := tenantRecords[payee.ID()]; ok {
+		record.Sequence = existing.Sequence
+		record.DeletedAt = existing.DeletedAt
+	} else {
+		r.sequence++
+		record.Sequence = r.sequence
+	}
+
+	tenantRecords[payee.ID()] = record
+
+	return nil
+}
+
+// checkUniqueness ensures document and pix keys of record do not belong to other active payees,
+// as unique indexes of a database would do over blind indexes. Empty indexes of anonymized records
+// are not unique, as null columns. Conflicting values come from payee, as record values are encrypted
+func checkUniqueness(tenantRecords map[string]*payeeRecord, record *payeeRecord, payee *domain.PayeeEntity) error {
+	for _, other := range tenantRecords {
+		if other.ID == record.ID || other.DeletedAt != nil {
+			continue
+		}
+
+		if record.DocumentIndex != "" && other.DocumentIndex == record.DocumentIndex {
+			return &application.ConflictError{
+				Field:           application.DocumentConflictField,
+				Value:           payee.Document().Value(),
+				ExistingPayeeID: other.ID,
+			}
+		}
+
+		for i, key := range record.PixKeys {
+			for _, otherKey := range other.PixKeys {
+				if key.ValueIndex != "" && key.Type == otherKey.Type && key.ValueIndex == otherKey.ValueIndex {
+					return &application.ConflictError{
+						Field:           application.PixKeyConflictField,
+						Value:           key.Type + ":" + payee.PixKeys()[i].Value(),
+						ExistingPayeeID: other.ID,
+					}
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+func (r *PayeeRepository) FindByID(ctx context.Context, tenantID string, payeeID string) (*domain.PayeeEntity, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	record, ok := r.records[tenantID][payeeID]
+	if !ok || record.DeletedAt != nil {
+		return nil, application.ErrPayeeNotFound
+	}
+
+	return record.restore(ctx, r.fields)
+}
+
+func (r *PayeeRepository) List(ctx context.Context, tenantID string, query application.ListPayeesQuery) ([]*domain.PayeeEntity, int, error) {
+	search := strings.TrimSpace(query.Search)
+
+	indexes, err := newSearchIndexes(ctx, r.fields, tenantID, search)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	cnpjRootIndex, err := r.fields.BlindIndex(ctx, tenantID, cnpjRootIndexField, query.CNPJRoot)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	type rankedRecord struct {
+		*payeeRecord
+		rank matchRank
+	}
+
+	var matched []rankedRecord
+	for _, record := range r.records[tenantID] {
+		if query.PersonKind != "" && domain.DocumentType(record.DocumentType).PersonKind() != query.PersonKind {
+			continue
+		}
+
+		if query.CNPJRoot != "" && record.CNPJRootIndex != cnpjRootIndex {
+			continue
+		}
+
+		if record.DeletedAt != nil {
+			continue
+		}
+
+		if rank, ok := record.match(search, indexes); ok {
+			matched = append(matched, rankedRecord{record, rank})
+		}
+	}
+
+	// best matches first, then insertion order
+	sort.Slice(matched, func(i, j int) bool {
+		if matched[i].rank != matched[j].rank {
+			return matched[i].rank < matched[j].rank
+		}
+
+		return matched[i].Sequence < matched[j].Sequence
+	})
+
+	total := len(matched)
+
+	start := min(query.Offset(), total)
+	end := min(start+query.Size, total)
+
+	payees := make([]*domain.PayeeEntity, 0, end-start)
+	for _, record := range matched[start:end] {
+		payee, err := record.restore(ctx, r.fields)
+		if err != nil {
+			return nil, 0, err
+		}
+
+		payees = append(payees, payee)
+	}
+
+	return payees, total, nil
+}
+
+func (r *PayeeRepository) FindByDataSubject(ctx context.Context, tenantID string, cpf string) ([]application.StoredPayee, error) {
+	documentIndex, err := r.fields.BlindIndex(ctx, tenantID, documentIndexField, cpf)
+	if err != nil {
+		return nil, err
+	}
+
+	pixKeyIndex, err := r.fields.BlindIndex(ctx, tenantID, pixKeyIndexField, cpf)
+	if err != nil {
+		return nil, err
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var matched []*payeeRecord
+	for _, record := range r.records[tenantID] {
+		if record.matchDataSubject(documentIndex, pixKeyIndex) {
+			matched = append(matched, record)
+		}
+	}
+
+	sort.Slice(matched, func(i, j int) bool {
+		return matched[i].Sequence < matched[j].Sequence
+	})
+
+	payees := make([]application.StoredPayee, 0, len(matched))
+	for _, record := range matched {
+		payee, err := record.restore(ctx, r.fields)
+		if err != nil {
+			return nil, err
+		}
+
+		payees = append(payees, application.StoredPayee{Payee: payee, DeletedAt: record.DeletedAt})
+	}
+
+	return payees, nil
+}
+
+// matchDataSubject reports if cpf blind indexes match record document or any of its CPF pix keys
+func (r *payeeRecord) matchDataSubject(documentIndex, pixKeyIndex string) bool {
+	if documentIndex != "" && r.DocumentIndex == documentIndex {
+		return true
+	}
+
+	for _, key := range r.PixKeys {
+		if key.Type == domain.CPFPixKeyType && pixKeyIndex != "" && key.ValueIndex == pixKeyIndex {
+			return true
+		}
+	}
+
+	return false
+}
+
+// countCreatedSince returns how many records were created since the given time, deleted ones included
+func countCreatedSince(tenantRecords map[string]*payeeRecord, since time.Time) int {
+	count := 0
+	for _, record := range tenantRecords {
+		if !record.CreatedAt.Before(since) {
+			count++
+		}
+	}
+
+	return count
+}
+
+func (r *PayeeRepository) Delete(_ context.Context, tenantID string, payeeIDs []string) ([]string, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	now := time.Now().UTC()
+
+	var deleted []string
+	for _, id := range payeeIDs {
+		record, ok := r.records[tenantID][id]
+		if !ok || record.DeletedAt != nil {
+			continue
+		}
+
+		record.DeletedAt = &now
+		deleted = append(deleted, id)
+	}
+
+	return deleted, nil
+}
+
+// RewrapDataKeys wraps the data key of every record, deleted ones included, with the current key
+// encryption key, and returns how many were rewrapped. It runs after a key rotation, while
+// requests are served, so the previous key can be removed from key provider once it returns
+func (r *PayeeRepository) RewrapDataKeys(ctx context.Context) (int, error) {
+	r.mu.RLock()
+	var records []*payeeRecord
+	for _, tenantRecords := range r.records {
+		for _, record := range tenantRecords {
+			records = append(records, record)
+		}
+	}
+	r.mu.RUnlock()
+
+	rewrapped := 0
+	for _, record := range records {
+		r.mu.RLock()
+		wrapped := record.DataKey
+		r.mu.RUnlock()
+
+		key, ok, err := r.fields.Rewrap(ctx, wrapped)
+		if err != nil {
+			return rewrapped, err
+		}
+		if !ok {
+			continue
+		}
+
+		// records saved meanwhile are replaced by new ones, so updating the old one is harmless,
+		// but a concurrent rewrap may have updated it already
+		r.mu.Lock()
+		if record.DataKey.KeyID == wrapped.KeyID {
+			record.DataKey = key
+			rewrapped++
+		}
+		r.mu.Unlock()
+	}
+
+	return rewrapped, nil
+}
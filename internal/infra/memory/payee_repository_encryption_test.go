@@ -0,0 +1,115 @@
+package memory_test
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/italorfeitosa/payee-account-manager-api/internal/application"
+	"github.com/italorfeitosa/payee-account-manager-api/internal/domain"
+	"github.com/italorfeitosa/payee-account-manager-api/internal/infra/encryption"
+	"github.com/italorfeitosa/payee-account-manager-api/internal/infra/memory"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeKeyFile(t *testing.T, path, current string, keys map[string][]byte, indexKey []byte) {
+	t.Helper()
+
+	encoded := make(map[string]string, len(keys))
+	for id, key := range keys {
+		encoded[id] = base64.StdEncoding.EncodeToString(key)
+	}
+
+	content, err := json.Marshal(map[string]any{
+		"current_key_id": current,
+		"keys":           encoded,
+		"index_key":      base64.StdEncoding.EncodeToString(indexKey),
+	})
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(path, content, 0o600))
+}
+
+func randomKey(t *testing.T) []byte {
+	t.Helper()
+
+	key := make([]byte, encryption.KeySize)
+	_, err := rand.Read(key)
+	require.NoError(t, err)
+
+	return key
+}
+
+func TestPayeeRepository_Encryption(t *testing.T) {
+	ctx := context.Background()
+	tenantID := uuid.NewString()
+	path := filepath.Join(t.TempDir(), "keys.json")
+	indexKey, oldKey, newKey := randomKey(t), randomKey(t), randomKey(t)
+
+	writeKeyFile(t, path, "old", map[string][]byte{"old": oldKey}, indexKey)
+	provider, err := encryption.NewFileKeyProvider(path)
+	require.NoError(t, err)
+	repository := memory.NewPayeeRepository(encryption.NewFieldEncrypter(provider))
+
+	payee := createPayee(t, "Italo Feitosa", "99818083008")
+	bankAccount := domain.BankAccount{AccountType: "CONTA_CORRENTE", AccountNumber: "65465465", AccountDigit: "5", BranchNumber: "0001", BankCode: "1", BankIspb: "54545"}
+	require.NoError(t, payee.Validate(bankAccount))
+	require.NoError(t, repository.Save(ctx, tenantID, payee))
+
+	search := func(t *testing.T, term string) []*domain.PayeeEntity {
+		t.Helper()
+
+		got, _, err := repository.List(ctx, tenantID, application.ListPayeesQuery{Page: 1, Size: 10, Search: term})
+		require.NoError(t, err)
+
+		return got
+	}
+
+	t.Run("given exact search by encrypted fields should match by blind index", func(t *testing.T) {
+		for _, term := range []string{"99818083008", payee.PixKey().Value(), "65465465"} {
+			got := search(t, term)
+
+			require.Len(t, got, 1, term)
+			assert.Equal(t, payee.ID(), got[0].ID())
+		}
+	})
+
+	t.Run("given search from another tenant should not match by blind index", func(t *testing.T) {
+		got, _, err := repository.List(ctx, uuid.NewString(), application.ListPayeesQuery{Page: 1, Size: 10, Search: "99818083008"})
+
+		require.NoError(t, err)
+		assert.Empty(t, got)
+	})
+
+	t.Run("given key rotation should restore payees while data keys are rewrapped", func(t *testing.T) {
+		writeKeyFile(t, path, "new", map[string][]byte{"old": oldKey, "new": newKey}, indexKey)
+		require.NoError(t, provider.Reload())
+
+		other := createPayee(t, "Maria Silva", "77386735081")
+		require.NoError(t, repository.Save(ctx, tenantID, other))
+
+		got, err := repository.FindByID(ctx, tenantID, payee.ID())
+		require.NoError(t, err)
+		assert.Equal(t, payee.Document(), got.Document())
+
+		rewrapped, err := repository.RewrapDataKeys(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, 1, rewrapped)
+
+		// previous key is removed once every data key is rewrapped
+		writeKeyFile(t, path, "new", map[string][]byte{"new": newKey}, indexKey)
+		require.NoError(t, provider.Reload())
+
+		got, err = repository.FindByID(ctx, tenantID, payee.ID())
+		require.NoError(t, err)
+		assert.Equal(t, payee.Document(), got.Document())
+		assert.Equal(t, payee.PixKey(), got.PixKey())
+		assert.Equal(t, &bankAccount, got.BankAccount())
+		assert.Len(t, search(t, "65465465"), 1)
+	})
+}
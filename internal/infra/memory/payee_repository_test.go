@@ -0,0 +1,543 @@
+package memory_test
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/italorfeitosa/payee-account-manager-api/internal/application"
+	"github.com/italorfeitosa/payee-account-manager-api/internal/domain"
+	"github.com/italorfeitosa/payee-account-manager-api/internal/infra/encryption"
+	"github.com/italorfeitosa/payee-account-manager-api/internal/infra/memory"
+	"github.com/italorfeitosa/payee-account-manager-api/test/fake"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newFieldEncrypter(t *testing.T) *encryption.FieldEncrypter {
+	t.Helper()
+
+	ring, err := encryption.NewKeyRing("test", map[string][]byte{"test": randomKey(t)}, randomKey(t))
+	require.NoError(t, err)
+
+	return encryption.NewFieldEncrypter(ring)
+}
+
+func newPayeeRepository(t *testing.T) *memory.PayeeRepository {
+	t.Helper()
+
+	return memory.NewPayeeRepository(newFieldEncrypter(t))
+}
+
+func createPayee(t *testing.T, name, document string) *domain.PayeeEntity {
+	t.Helper()
+
+	payee, err := domain.CreatePayee(name, document, domain.ChaveAleatoriaPixKeyType, uuid.NewString(), "italo@feitosa.com")
+	require.NoError(t, err)
+
+	return payee
+}
+
+func deletePayees(t *testing.T, repository *memory.PayeeRepository, tenantID string, payeeIDs []string) {
+	t.Helper()
+
+	_, err := repository.Delete(context.Background(), tenantID, payeeIDs)
+	require.NoError(t, err)
+}
+
+func TestPayeeRepository_SaveAndFindByID(t *testing.T) {
+	ctx := context.Background()
+	repository := newPayeeRepository(t)
+	tenantID := uuid.NewString()
+
+	payee := createPayee(t, "Italo Feitosa", "99818083008")
+	require.NoError(t, repository.Save(ctx, tenantID, payee))
+
+	t.Run("given a saved payee should restore all values", func(t *testing.T) {
+		got, err := repository.FindByID(ctx, tenantID, payee.ID())
+
+		require.NoError(t, err)
+		assert.Equal(t, payee.ID(), got.ID())
+		assert.Equal(t, payee.Name(), got.Name())
+		assert.Equal(t, payee.Document(), got.Document())
+		assert.Equal(t, payee.Email(), got.Email())
+		assert.Equal(t, payee.Status(), got.Status())
+		assert.Equal(t, payee.PixKey(), got.PixKey())
+		assert.Equal(t, payee.CreatedAt(), got.CreatedAt())
+		assert.Equal(t, payee.UpdatedAt(), got.UpdatedAt())
+	})
+
+	t.Run("given another tenant should not find payee", func(t *testing.T) {
+		_, err := repository.FindByID(ctx, uuid.NewString(), payee.ID())
+
+		assert.ErrorIs(t, err, application.ErrPayeeNotFound)
+	})
+
+	t.Run("given a deleted payee should not find payee", func(t *testing.T) {
+		deletePayees(t, repository, tenantID, []string{payee.ID()})
+
+		_, err := repository.FindByID(ctx, tenantID, payee.ID())
+
+		assert.ErrorIs(t, err, application.ErrPayeeNotFound)
+	})
+}
+
+func TestPayeeRepository_List(t *testing.T) {
+	ctx := context.Background()
+	repository := newPayeeRepository(t)
+	tenantID := uuid.NewString()
+
+	payees := []*domain.PayeeEntity{
+		createPayee(t, "Italo Feitosa", "99818083008"),
+		createPayee(t, "Maria Silva", "77386735081"),
+		createPayee(t, "Joana Feitosa", "19039318000104"),
+	}
+	for _, payee := range payees {
+		require.NoError(t, repository.Save(ctx, tenantID, payee))
+	}
+
+	tests := []struct {
+		name      string
+		query     application.ListPayeesQuery
+		wantIDs   []string
+		wantTotal int
+	}{
+		{
+			name:      "given no search should return all payees of page",
+			query:     application.ListPayeesQuery{Page: 1, Size: 2},
+			wantIDs:   []string{payees[0].ID(), payees[1].ID()},
+			wantTotal: 3,
+		},
+		{
+			name:      "given second page should return remaining payees",
+			query:     application.ListPayeesQuery{Page: 2, Size: 2},
+			wantIDs:   []string{payees[2].ID()},
+			wantTotal: 3,
+		},
+		{
+			name:      "given a search by name should return matching payees",
+			query:     application.ListPayeesQuery{Page: 1, Size: 10, Search: "feitosa"},
+			wantIDs:   []string{payees[0].ID(), payees[2].ID()},
+			wantTotal: 2,
+		},
+		{
+			name:      "given a search by cpf_cnpj should return matching payee",
+			query:     application.ListPayeesQuery{Page: 1, Size: 10, Search: "77386735081"},
+			wantIDs:   []string{payees[1].ID()},
+			wantTotal: 1,
+		},
+		{
+			name:      "given a cnpj root should return payees of company",
+			query:     application.ListPayeesQuery{Page: 1, Size: 10, CNPJRoot: "19039318"},
+			wantIDs:   []string{payees[2].ID()},
+			wantTotal: 1,
+		},
+		{
+			name:      "given a partial cpf_cnpj should not match encrypted document",
+			query:     application.ListPayeesQuery{Page: 1, Size: 10, Search: "773867"},
+			wantIDs:   []string{},
+			wantTotal: 0,
+		},
+		{
+			name:      "given a person kind should return payees of kind",
+			query:     application.ListPayeesQuery{Page: 1, Size: 10, PersonKind: domain.LegalPersonKind},
+			wantIDs:   []string{payees[2].ID()},
+			wantTotal: 1,
+		},
+		{
+			name:      "given a person kind and search should return payees matching both",
+			query:     application.ListPayeesQuery{Page: 1, Size: 10, PersonKind: domain.NaturalPersonKind, Search: "feitosa"},
+			wantIDs:   []string{payees[0].ID()},
+			wantTotal: 1,
+		},
+		{
+			name:      "given a search without matches should return empty page",
+			query:     application.ListPayeesQuery{Page: 1, Size: 10, Search: "nobody"},
+			wantIDs:   []string{},
+			wantTotal: 0,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, total, err := repository.List(ctx, tenantID, tt.query)
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantTotal, total)
+
+			gotIDs := make([]string, 0, len(got))
+			for _, payee := range got {
+				gotIDs = append(gotIDs, payee.ID())
+			}
+			assert.Equal(t, tt.wantIDs, gotIDs)
+		})
+	}
+}
+
+func TestPayeeRepository_ListRanking(t *testing.T) {
+	ctx := context.Background()
+	repository := newPayeeRepository(t)
+	tenantID := uuid.NewString()
+
+	payees := []*domain.PayeeEntity{
+		createPayee(t, "Maria João Silva", "99818083008"),
+		createPayee(t, "Joãozinho Feitosa", "77386735081"),
+		createPayee(t, "João", "19039318000104"),
+	}
+	for _, payee := range payees {
+		require.NoError(t, repository.Save(ctx, tenantID, payee))
+	}
+
+	tests := []struct {
+		name    string
+		search  string
+		wantIDs []string
+	}{
+		{
+			name:    "given a search without accents should rank exact, prefix and then contains matches",
+			search:  "joao",
+			wantIDs: []string{payees[2].ID(), payees[1].ID(), payees[0].ID()},
+		},
+		{
+			name:    "given a search with upper case, accents and extra spaces should match normalized names",
+			search:  "  MARIA   JOÃO ",
+			wantIDs: []string{payees[0].ID()},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, total, err := repository.List(ctx, tenantID, application.ListPayeesQuery{Page: 1, Size: 10, Search: tt.search})
+
+			require.NoError(t, err)
+			assert.Equal(t, len(tt.wantIDs), total)
+
+			gotIDs := make([]string, 0, len(got))
+			for _, payee := range got {
+				gotIDs = append(gotIDs, payee.ID())
+			}
+			assert.Equal(t, tt.wantIDs, gotIDs)
+		})
+	}
+}
+
+func TestPayeeRepository_ListFormattedSearch(t *testing.T) {
+	ctx := context.Background()
+	repository := newPayeeRepository(t)
+	tenantID := uuid.NewString()
+
+	person := createPayee(t, "Italo Feitosa", "99818083008")
+	require.NoError(t, person.AddPixKey(domain.TelefonePixKeyType, "11912345678"))
+	company := createPayee(t, "Fake Company", "12ABC34501DE35")
+	for _, payee := range []*domain.PayeeEntity{person, company} {
+		require.NoError(t, repository.Save(ctx, tenantID, payee))
+	}
+
+	tests := []struct {
+		name   string
+		search string
+		wantID string
+	}{
+		{name: "given a formatted cpf should return payee", search: "998.180.830-08", wantID: person.ID()},
+		{name: "given a formatted cnpj should return payee", search: "12.ABC.345/01DE-35", wantID: company.ID()},
+		{name: "given an alphanumeric cnpj in lower case should return payee", search: "12abc34501de35", wantID: company.ID()},
+		{name: "given a formatted telefone pix key should return payee", search: "+55 (11) 91234-5678", wantID: person.ID()},
+		{name: "given a telefone pix key without country code should return payee", search: "11912345678", wantID: person.ID()},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, total, err := repository.List(ctx, tenantID, application.ListPayeesQuery{Page: 1, Size: 10, Search: tt.search})
+
+			require.NoError(t, err)
+			require.Equal(t, 1, total)
+			assert.Equal(t, tt.wantID, got[0].ID())
+		})
+	}
+}
+
+func TestPayeeRepository_PixKeys(t *testing.T) {
+	ctx := context.Background()
+	repository := newPayeeRepository(t)
+	tenantID := uuid.NewString()
+
+	payee := createPayee(t, "Italo Feitosa", "99818083008")
+	require.NoError(t, payee.AddPixKey(domain.EmailPixKeyType, "pix@feitosa.com"))
+	require.NoError(t, payee.SetPrimaryPixKey(domain.EmailPixKeyType, "pix@feitosa.com"))
+	require.NoError(t, repository.Save(ctx, tenantID, payee))
+
+	t.Run("given a payee with many pix keys should restore all keys keeping primary", func(t *testing.T) {
+		got, err := repository.FindByID(ctx, tenantID, payee.ID())
+
+		require.NoError(t, err)
+		assert.Equal(t, payee.PixKeys(), got.PixKeys())
+		assert.Equal(t, "pix@feitosa.com", got.PixKey().Value())
+	})
+
+	t.Run("given a search by non primary pix key should return payee", func(t *testing.T) {
+		got, total, err := repository.List(ctx, tenantID, application.ListPayeesQuery{Page: 1, Size: 10, Search: payee.PixKeys()[1].Value()})
+
+		require.NoError(t, err)
+		assert.Equal(t, 1, total)
+		assert.Equal(t, payee.ID(), got[0].ID())
+	})
+}
+
+func TestPayeeRepository_Uniqueness(t *testing.T) {
+	ctx := context.Background()
+	tenantID := uuid.NewString()
+
+	t.Run("given a document of another payee should return conflict", func(t *testing.T) {
+		repository := newPayeeRepository(t)
+		existing := createPayee(t, "Italo Feitosa", "99818083008")
+		require.NoError(t, repository.Save(ctx, tenantID, existing))
+
+		err := repository.Save(ctx, tenantID, createPayee(t, "Maria Silva", "998.180.830-08"))
+
+		var conflict *application.ConflictError
+		require.ErrorAs(t, err, &conflict)
+		assert.ErrorIs(t, err, application.ErrPayeeConflict)
+		assert.Equal(t, application.DocumentConflictField, conflict.Field)
+		assert.Equal(t, existing.ID(), conflict.ExistingPayeeID)
+	})
+
+	t.Run("given a pix key of another payee should return conflict", func(t *testing.T) {
+		repository := newPayeeRepository(t)
+		existing := createPayee(t, "Italo Feitosa", "99818083008")
+		require.NoError(t, existing.AddPixKey(domain.EmailPixKeyType, "pix@feitosa.com"))
+		require.NoError(t, repository.Save(ctx, tenantID, existing))
+
+		payee := createPayee(t, "Maria Silva", "77386735081")
+		require.NoError(t, payee.AddPixKey(domain.EmailPixKeyType, "pix@feitosa.com"))
+		err := repository.Save(ctx, tenantID, payee)
+
+		var conflict *application.ConflictError
+		require.ErrorAs(t, err, &conflict)
+		assert.Equal(t, application.PixKeyConflictField, conflict.Field)
+		assert.Equal(t, "EMAIL:pix@feitosa.com", conflict.Value)
+		assert.Equal(t, existing.ID(), conflict.ExistingPayeeID)
+	})
+
+	t.Run("given a document of another tenant payee should save", func(t *testing.T) {
+		repository := newPayeeRepository(t)
+		require.NoError(t, repository.Save(ctx, tenantID, createPayee(t, "Italo Feitosa", "99818083008")))
+
+		err := repository.Save(ctx, uuid.NewString(), createPayee(t, "Italo Feitosa", "99818083008"))
+
+		assert.NoError(t, err)
+	})
+
+	t.Run("given a document of a deleted payee should save", func(t *testing.T) {
+		repository := newPayeeRepository(t)
+		existing := createPayee(t, "Italo Feitosa", "99818083008")
+		require.NoError(t, repository.Save(ctx, tenantID, existing))
+		deletePayees(t, repository, tenantID, []string{existing.ID()})
+
+		err := repository.Save(ctx, tenantID, createPayee(t, "Italo Feitosa", "99818083008"))
+
+		assert.NoError(t, err)
+	})
+
+	t.Run("given an update of same payee should save", func(t *testing.T) {
+		repository := newPayeeRepository(t)
+		existing := createPayee(t, "Italo Feitosa", "99818083008")
+		require.NoError(t, repository.Save(ctx, tenantID, existing))
+
+		err := repository.Save(ctx, tenantID, existing)
+
+		assert.NoError(t, err)
+	})
+}
+
+func TestPayeeRepository_SaveWithinQuota(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("given payees created since should count them including deleted", func(t *testing.T) {
+		repository := newPayeeRepository(t)
+		tenantID := uuid.NewString()
+		since := time.Now().UTC()
+
+		require.NoError(t, repository.SaveWithinQuota(ctx, tenantID, createPayee(t, "Italo Feitosa", "99818083008"), since, 2))
+
+		deleted := createPayee(t, "Fake Company", "19039318000104")
+		require.NoError(t, repository.SaveWithinQuota(ctx, tenantID, deleted, since, 2))
+		deletePayees(t, repository, tenantID, []string{deleted.ID()})
+
+		err := repository.SaveWithinQuota(ctx, tenantID, createPayee(t, "Other Person", "52998224725"), since, 2)
+
+		assert.ErrorIs(t, err, application.ErrRegistrationQuotaExceeded)
+	})
+
+	t.Run("given payees created before since should not count them", func(t *testing.T) {
+		repository := newPayeeRepository(t)
+		tenantID := uuid.NewString()
+		require.NoError(t, repository.Save(ctx, tenantID, createPayee(t, "Italo Feitosa", "99818083008")))
+
+		err := repository.SaveWithinQuota(ctx, tenantID, createPayee(t, "Other Person", "52998224725"), time.Now().UTC().Add(time.Second), 1)
+
+		assert.NoError(t, err)
+	})
+
+	t.Run("given payees of another tenant should not count them", func(t *testing.T) {
+		repository := newPayeeRepository(t)
+		since := time.Now().UTC()
+		require.NoError(t, repository.Save(ctx, uuid.NewString(), createPayee(t, "Other Tenant", "99818083008")))
+
+		err := repository.SaveWithinQuota(ctx, uuid.NewString(), createPayee(t, "Italo Feitosa", "99818083008"), since, 1)
+
+		assert.NoError(t, err)
+	})
+
+	t.Run("given concurrent registrations should not go past limit", func(t *testing.T) {
+		repository := newPayeeRepository(t)
+		tenantID := uuid.NewString()
+		since := time.Now().UTC()
+		limit := 5
+
+		var saved atomic.Int64
+		var wg sync.WaitGroup
+		for range 50 {
+			payee := createPayee(t, fake.Name(), fake.CPF())
+
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+
+				err := repository.SaveWithinQuota(ctx, tenantID, payee, since, limit)
+				if err == nil {
+					saved.Add(1)
+					return
+				}
+
+				assert.ErrorIs(t, err, application.ErrRegistrationQuotaExceeded)
+			}()
+		}
+		wg.Wait()
+
+		assert.EqualValues(t, limit, saved.Load())
+	})
+}
+
+func TestPayeeRepository_Delete(t *testing.T) {
+	ctx := context.Background()
+	repository := newPayeeRepository(t)
+	tenantID := uuid.NewString()
+
+	payee := createPayee(t, "Italo Feitosa", "99818083008")
+	require.NoError(t, repository.Save(ctx, tenantID, payee))
+
+	t.Run("given existing and unknown ids should return only deleted ids", func(t *testing.T) {
+		deleted, err := repository.Delete(ctx, tenantID, []string{payee.ID(), uuid.NewString()})
+
+		require.NoError(t, err)
+		assert.Equal(t, []string{payee.ID()}, deleted)
+	})
+
+	t.Run("given an already deleted id should not return it", func(t *testing.T) {
+		deleted, err := repository.Delete(ctx, tenantID, []string{payee.ID()})
+
+		require.NoError(t, err)
+		assert.Empty(t, deleted)
+	})
+
+	t.Run("given an id of another tenant should not delete it", func(t *testing.T) {
+		other := createPayee(t, "Italo Feitosa", "99818083008")
+		require.NoError(t, repository.Save(ctx, uuid.NewString(), other))
+
+		deleted, err := repository.Delete(ctx, tenantID, []string{other.ID()})
+
+		require.NoError(t, err)
+		assert.Empty(t, deleted)
+	})
+}
+
+func TestPayeeRepository_FindByDataSubject(t *testing.T) {
+	ctx := context.Background()
+	repository := newPayeeRepository(t)
+	tenantID := uuid.NewString()
+	cpf := "99818083008"
+
+	deleted := createPayee(t, "Italo Feitosa", cpf)
+	require.NoError(t, repository.Save(ctx, tenantID, deleted))
+	deletePayees(t, repository, tenantID, []string{deleted.ID()})
+
+	active := createPayee(t, "Italo Rodrigues Feitosa", cpf)
+	require.NoError(t, repository.Save(ctx, tenantID, active))
+
+	company := createPayee(t, "Fake Company", "19039318000104")
+	require.NoError(t, company.AddPixKey(domain.CPFPixKeyType, cpf))
+	require.NoError(t, repository.Save(ctx, tenantID, company))
+
+	require.NoError(t, repository.Save(ctx, tenantID, createPayee(t, "Other Person", "52998224725")))
+	require.NoError(t, repository.Save(ctx, uuid.NewString(), createPayee(t, "Other Tenant", cpf)))
+
+	t.Run("given a cpf should return payees by document and cpf pix key including deleted", func(t *testing.T) {
+		payees, err := repository.FindByDataSubject(ctx, tenantID, cpf)
+
+		require.NoError(t, err)
+		require.Len(t, payees, 3)
+		assert.Equal(t, deleted.ID(), payees[0].Payee.ID())
+		assert.NotNil(t, payees[0].DeletedAt)
+		assert.Equal(t, active.ID(), payees[1].Payee.ID())
+		assert.Nil(t, payees[1].DeletedAt)
+		assert.Equal(t, company.ID(), payees[2].Payee.ID())
+	})
+
+	t.Run("given a cpf of no payee should return empty", func(t *testing.T) {
+		payees, err := repository.FindByDataSubject(ctx, tenantID, "11144477735")
+
+		require.NoError(t, err)
+		assert.Empty(t, payees)
+	})
+
+	t.Run("given anonymized payees should not return them", func(t *testing.T) {
+		payees, err := repository.FindByDataSubject(ctx, tenantID, cpf)
+		require.NoError(t, err)
+
+		for _, payee := range payees {
+			payee.Payee.Anonymize()
+			require.NoError(t, repository.Save(ctx, tenantID, payee.Payee))
+		}
+
+		payees, err = repository.FindByDataSubject(ctx, tenantID, cpf)
+
+		require.NoError(t, err)
+		assert.Empty(t, payees)
+	})
+}
+
+func TestPayeeRepository_Anonymized(t *testing.T) {
+	ctx := context.Background()
+	repository := newPayeeRepository(t)
+	tenantID := uuid.NewString()
+
+	first := createPayee(t, "Italo Feitosa", "99818083008")
+	first.Anonymize()
+	require.NoError(t, repository.Save(ctx, tenantID, first))
+
+	t.Run("given an anonymized payee should restore it anonymized", func(t *testing.T) {
+		got, err := repository.FindByID(ctx, tenantID, first.ID())
+
+		require.NoError(t, err)
+		assert.True(t, got.Anonymized())
+		assert.Equal(t, first.Status(), got.Status())
+		assert.Empty(t, got.Name())
+		assert.Equal(t, domain.AnonymizedDocumentType, got.DocumentType())
+		assert.Equal(t, domain.AnonymizedPixKeyType, got.PixKey().Type())
+	})
+
+	t.Run("given many anonymized payees should not conflict", func(t *testing.T) {
+		second := createPayee(t, "Fake Company", "19039318000104")
+		second.Anonymize()
+
+		err := repository.Save(ctx, tenantID, second)
+
+		assert.NoError(t, err)
+	})
+
+	t.Run("given the document of an anonymized payee should save", func(t *testing.T) {
+		err := repository.Save(ctx, tenantID, createPayee(t, "Italo Feitosa", "99818083008"))
+
+		assert.NoError(t, err)
+	})
+}
@@ -0,0 +1,3 @@
+// receita package implements application.DocumentStatusChecker adapters,
+// which query the cadastral status of CPF and CNPJ in Receita Federal
+package receita
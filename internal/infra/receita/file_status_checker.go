@@ -0,0 +1,61 @@
+package receita
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/italorfeitosa/payee-account-manager-api/internal/application"
+	"github.com/italorfeitosa/payee-account-manager-api/internal/domain"
+)
+
+type registrationEntry struct {
+	Status       string `json:"status"`
+	OfficialName string `json:"official_name"`
+}
+
+// FileStatusChecker is a fake application.DocumentStatusChecker for local runs, which loads
+// registrations from a json file keyed by document value without formatting:
+//
+//	{
+//	    "99818083008": {"status": "REGULAR", "official_name": "ITALO FEITOSA"},
+//	    "19039318000104": {"status": "SUSPENDED", "official_name": "THE FAKE COMPANY LTDA"}
+//	}
+type FileStatusChecker struct {
+	registrations map[string]domain.DocumentRegistration
+}
+
+var _ application.DocumentStatusChecker = (*FileStatusChecker)(nil)
+
+// NewFileStatusChecker loads registrations from file at path
+func NewFileStatusChecker(path string) (*FileStatusChecker, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read document registrations file: %w", err)
+	}
+
+	var entries map[string]registrationEntry
+	if err := json.Unmarshal(content, &entries); err != nil {
+		return nil, fmt.Errorf("decode document registrations file: %w", err)
+	}
+
+	registrations := make(map[string]domain.DocumentRegistration, len(entries))
+	for document, entry := range entries {
+		registrations[document] = domain.DocumentRegistration{
+			Status:       domain.DocumentRegistrationStatus(entry.Status),
+			OfficialName: entry.OfficialName,
+		}
+	}
+
+	return &FileStatusChecker{registrations}, nil
+}
+
+func (c *FileStatusChecker) Check(_ context.Context, document domain.Document) (domain.DocumentRegistration, error) {
+	registration, ok := c.registrations[document.Value()]
+	if !ok {
+		return domain.DocumentRegistration{}, application.ErrDocumentRegistrationNotFound
+	}
+
+	return registration, nil
+}
@@ -0,0 +1,48 @@
+package receita_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/italorfeitosa/payee-account-manager-api/internal/application"
+	"github.com/italorfeitosa/payee-account-manager-api/internal/domain"
+	"github.com/italorfeitosa/payee-account-manager-api/internal/infra/receita"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFileStatusChecker(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "registrations.json")
+	require.NoError(t, os.WriteFile(path, []byte(`{
+		"99818083008": {"status": "REGULAR", "official_name": "ITALO FEITOSA"},
+		"19039318000104": {"status": "SUSPENDED", "official_name": "THE FAKE COMPANY LTDA"}
+	}`), 0o600))
+
+	checker, err := receita.NewFileStatusChecker(path)
+	require.NoError(t, err)
+
+	t.Run("given a registered document should return its registration", func(t *testing.T) {
+		document, _ := domain.NewDocument("998.180.830-08")
+
+		got, err := checker.Check(context.Background(), document)
+
+		require.NoError(t, err)
+		assert.Equal(t, domain.DocumentRegistration{Status: domain.RegularRegistrationStatus, OfficialName: "ITALO FEITOSA"}, got)
+	})
+
+	t.Run("given an unknown document should return error", func(t *testing.T) {
+		document, _ := domain.NewDocument("77386735081")
+
+		_, err := checker.Check(context.Background(), document)
+
+		assert.ErrorIs(t, err, application.ErrDocumentRegistrationNotFound)
+	})
+
+	t.Run("given an invalid file should return error", func(t *testing.T) {
+		_, err := receita.NewFileStatusChecker(filepath.Join(t.TempDir(), "missing.json"))
+
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,20 @@
+package smtp
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/italorfeitosa/payee-account-manager-api/internal/application"
+)
+
+// DiscardMailer is an application.Mailer for when no SMTP server is configured, it drops
+// messages without keeping them, as they carry verification tokens, and logs only their subject
+type DiscardMailer struct{}
+
+var _ application.Mailer = DiscardMailer{}
+
+func (DiscardMailer) Send(ctx context.Context, message application.EmailMessage) error {
+	slog.WarnContext(ctx, "email discarded, smtp addr is not set", slog.String("subject", message.Subject))
+
+	return nil
+}
@@ -0,0 +1,2 @@
+// smtp package implements application.Mailer delivering emails through a SMTP server
+package smtp
@@ -0,0 +1,184 @@
+package smtp_test
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"net"
+	"net/textproto"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/italorfeitosa/payee-account-manager-api/internal/application"
+	"github.com/italorfeitosa/payee-account-manager-api/internal/infra/smtp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// received is the envelope and data of a message delivered to fake SMTP server
+type received struct {
+	from string
+	to   string
+	data string
+}
+
+// startFakeServer accepts one SMTP session and sends the received message to channel
+func startFakeServer(t *testing.T) (string, <-chan received) {
+	t.Helper()
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	t.Cleanup(func() { listener.Close() })
+
+	messages := make(chan received, 1)
+
+	go func() {
+		conn, err := listener.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+
+		text := textproto.NewConn(conn)
+		var message received
+
+		text.PrintfLine("220 fake smtp")
+		for {
+			line, err := text.ReadLine()
+			if err != nil {
+				return
+			}
+
+			command := strings.ToUpper(line)
+			switch {
+			case strings.HasPrefix(command, "EHLO"), strings.HasPrefix(command, "HELO"):
+				text.PrintfLine("250 fake smtp")
+			case strings.HasPrefix(command, "MAIL FROM:"):
+				message.from = strings.Trim(line[len("MAIL FROM:"):], "<>")
+				text.PrintfLine("250 ok")
+			case strings.HasPrefix(command, "RCPT TO:"):
+				message.to = strings.Trim(line[len("RCPT TO:"):], "<>")
+				text.PrintfLine("250 ok")
+			case command == "DATA":
+				text.PrintfLine("354 send data")
+				data, err := text.ReadDotLines()
+				if err != nil {
+					return
+				}
+				message.data = strings.Join(data, "\n")
+				text.PrintfLine("250 ok")
+			case command == "QUIT":
+				text.PrintfLine("221 bye")
+				messages <- message
+				return
+			default:
+				text.PrintfLine("502 not implemented")
+			}
+		}
+	}()
+
+	return listener.Addr().String(), messages
+}
+
+func TestMailer_Send(t *testing.T) {
+	t.Run("given a message should deliver it to SMTP server", func(t *testing.T) {
+		addr, messages := startFakeServer(t)
+		mailer := smtp.NewMailer(addr, "noreply@payees.com", "", "", time.Second)
+
+		err := mailer.Send(context.Background(), application.EmailMessage{
+			To:      "italo@feitosa.com",
+			Subject: "Confirm your email",
+			Body:    "Hello Italo Feitosa,\nconfirm your email",
+		})
+
+		require.NoError(t, err)
+
+		message := <-messages
+		assert.Equal(t, "noreply@payees.com", message.from)
+		assert.Equal(t, "italo@feitosa.com", message.to)
+		assert.Contains(t, message.data, "To: italo@feitosa.com")
+		assert.Contains(t, message.data, "Subject: Confirm your email")
+		assert.Contains(t, message.data, "Hello Italo Feitosa,\nconfirm your email")
+	})
+
+	t.Run("given an unreachable server should return error", func(t *testing.T) {
+		listener, err := net.Listen("tcp", "127.0.0.1:0")
+		require.NoError(t, err)
+		addr := listener.Addr().String()
+		listener.Close()
+
+		mailer := smtp.NewMailer(addr, "noreply@payees.com", "", "", time.Second)
+
+		err = mailer.Send(context.Background(), application.EmailMessage{To: "italo@feitosa.com"})
+
+		assert.Error(t, err)
+	})
+
+	t.Run("given a server that does not respond should return error after timeout", func(t *testing.T) {
+		addr := startSilentServer(t)
+		mailer := smtp.NewMailer(addr, "noreply@payees.com", "", "", 50*time.Millisecond)
+
+		err := mailer.Send(context.Background(), application.EmailMessage{To: "italo@feitosa.com"})
+
+		assert.ErrorIs(t, err, context.DeadlineExceeded)
+	})
+
+	t.Run("given a canceled context should stop waiting for server", func(t *testing.T) {
+		addr := startSilentServer(t)
+		mailer := smtp.NewMailer(addr, "noreply@payees.com", "", "", time.Minute)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		time.AfterFunc(50*time.Millisecond, cancel)
+
+		err := mailer.Send(ctx, application.EmailMessage{To: "italo@feitosa.com"})
+
+		assert.ErrorIs(t, err, context.Canceled)
+	})
+}
+
+// startSilentServer accepts connections and never answers, as a stalled SMTP server
+func startSilentServer(t *testing.T) string {
+	t.Helper()
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	t.Cleanup(func() { listener.Close() })
+
+	go func() {
+		var conns []net.Conn
+		defer func() {
+			for _, conn := range conns {
+				conn.Close()
+			}
+		}()
+
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			conns = append(conns, conn)
+		}
+	}()
+
+	return listener.Addr().String()
+}
+
+func TestDiscardMailer_Send(t *testing.T) {
+	var buf bytes.Buffer
+	defaultLogger := slog.Default()
+	slog.SetDefault(slog.New(slog.NewJSONHandler(&buf, nil)))
+	t.Cleanup(func() { slog.SetDefault(defaultLogger) })
+
+	err := smtp.DiscardMailer{}.Send(context.Background(), application.EmailMessage{
+		To:      "italo@feitosa.com",
+		Subject: "Confirm your email",
+		Body:    "confirm with token secret-token",
+	})
+
+	require.NoError(t, err)
+	assert.Contains(t, buf.String(), "Confirm your email")
+	assert.NotContains(t, buf.String(), "secret-token")
+	assert.NotContains(t, buf.String(), "italo@feitosa.com")
+}
@@ -0,0 +1,3 @@
+// tracing package configures OpenTelemetry tracer providers and decorates
+// application.PayeeRepository adapters with spans
+package tracing
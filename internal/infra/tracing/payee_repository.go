@@ -0,0 +1,99 @@
+package tracing
+
+import (
+	"context"
+	"time"
+
+	"github.com/italorfeitosa/payee-account-manager-api/internal/application"
+	"github.com/italorfeitosa/payee-account-manager-api/internal/domain"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// TracerName is the instrumentation name of repository spans
+const TracerName = "github.com/italorfeitosa/payee-account-manager-api/internal/infra/tracing"
+
+var tracer = otel.Tracer(TracerName)
+
+// PayeeRepository starts a span around each operation of the decorated repository
+type PayeeRepository struct {
+	next application.PayeeRepository
+}
+
+var _ application.PayeeRepository = (*PayeeRepository)(nil)
+
+func NewPayeeRepository(next application.PayeeRepository) *PayeeRepository {
+	return &PayeeRepository{next}
+}
+
+func startSpan(ctx context.Context, operation string, attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+	return tracer.Start(ctx, "PayeeRepository."+operation, trace.WithSpanKind(trace.SpanKindClient), trace.WithAttributes(attrs...))
+}
+
+func (r *PayeeRepository) Save(ctx context.Context, tenantID string, payee *domain.PayeeEntity) (err error) {
+	ctx, span := startSpan(ctx, "Save", application.TenantIDAttribute.String(tenantID), application.PayeeIDAttribute.String(payee.ID()))
+	defer func() { application.EndSpan(span, err) }()
+
+	return r.next.Save(ctx, tenantID, payee)
+}
+
+func (r *PayeeRepository) FindByID(ctx context.Context, tenantID string, payeeID string) (payee *domain.PayeeEntity, err error) {
+	ctx, span := startSpan(ctx, "FindByID", application.TenantIDAttribute.String(tenantID), application.PayeeIDAttribute.String(payeeID))
+	defer func() { application.EndSpan(span, err) }()
+
+	return r.next.FindByID(ctx, tenantID, payeeID)
+}
+
+func (r *PayeeRepository) List(ctx context.Context, tenantID string, query application.ListPayeesQuery) (payees []*domain.PayeeEntity, total int, err error) {
+	ctx, span := startSpan(ctx, "List",
+		application.TenantIDAttribute.String(tenantID),
+		attribute.Int("query.page", query.Page),
+		attribute.Int("query.size", query.Size),
+	)
+	defer func() {
+		span.SetAttributes(attribute.Int("result.total", total))
+		application.EndSpan(span, err)
+	}()
+
+	return r.next.List(ctx, tenantID, query)
+}
+
+func (r *PayeeRepository) Delete(ctx context.Context, tenantID string, payeeIDs []string) (deleted []string, err error) {
+	ctx, span := startSpan(ctx, "Delete", application.TenantIDAttribute.String(tenantID), application.PayeeIDsAttribute.StringSlice(payeeIDs))
+	defer func() {
+		span.SetAttributes(attribute.Int("result.deleted", len(deleted)))
+		application.EndSpan(span, err)
+	}()
+
+	return r.next.Delete(ctx, tenantID, payeeIDs)
+}
+
+// FindByDataSubject does not add cpf to span, as it identifies the data subject
+func (r *PayeeRepository) FindByDataSubject(ctx context.Context, tenantID string, cpf string) (payees []application.StoredPayee, err error) {
+	ctx, span := startSpan(ctx, "FindByDataSubject", application.TenantIDAttribute.String(tenantID))
+	defer func() {
+		span.SetAttributes(attribute.Int("result.total", len(payees)))
+		application.EndSpan(span, err)
+	}()
+
+	return r.next.FindByDataSubject(ctx, tenantID, cpf)
+}
+
+func (r *PayeeRepository) SaveWithinQuota(ctx context.Context, tenantID string, payee *domain.PayeeEntity, since time.Time, limit int) (err error) {
+	ctx, span := startSpan(ctx, "SaveWithinQuota",
+		application.TenantIDAttribute.String(tenantID),
+		application.PayeeIDAttribute.String(payee.ID()),
+		attribute.Int("quota.limit", limit),
+	)
+	defer func() { application.EndSpan(span, err) }()
+
+	return r.next.SaveWithinQuota(ctx, tenantID, payee, since, limit)
+}
+
+func (r *PayeeRepository) Ping(ctx context.Context) (err error) {
+	ctx, span := startSpan(ctx, "Ping")
+	defer func() { application.EndSpan(span, err) }()
+
+	return r.next.Ping(ctx)
+}
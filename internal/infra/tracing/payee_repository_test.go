@@ -0,0 +1,91 @@
+package tracing_test
+
+import (
+	"context"
+	"crypto/rand"
+	"sync"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/italorfeitosa/payee-account-manager-api/internal/application"
+	"github.com/italorfeitosa/payee-account-manager-api/internal/infra/encryption"
+	"github.com/italorfeitosa/payee-account-manager-api/internal/infra/memory"
+	"github.com/italorfeitosa/payee-account-manager-api/internal/infra/tracing"
+	"github.com/italorfeitosa/payee-account-manager-api/test/fake"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+var (
+	setupTracing sync.Once
+	spanExporter *tracetest.InMemoryExporter
+)
+
+func recordSpans(t *testing.T) *tracetest.InMemoryExporter {
+	t.Helper()
+
+	setupTracing.Do(func() {
+		spanExporter = tracetest.NewInMemoryExporter()
+		tracing.Setup(sdktrace.NewTracerProvider(sdktrace.WithSyncer(spanExporter)))
+	})
+
+	spanExporter.Reset()
+
+	return spanExporter
+}
+
+func newPayeeRepository(t *testing.T) *memory.PayeeRepository {
+	t.Helper()
+
+	key, indexKey := make([]byte, encryption.KeySize), make([]byte, encryption.KeySize)
+	_, err := rand.Read(key)
+	require.NoError(t, err)
+	_, err = rand.Read(indexKey)
+	require.NoError(t, err)
+
+	ring, err := encryption.NewKeyRing("test", map[string][]byte{"test": key}, indexKey)
+	require.NoError(t, err)
+
+	return memory.NewPayeeRepository(encryption.NewFieldEncrypter(ring))
+}
+
+func TestPayeeRepository(t *testing.T) {
+	ctx := context.Background()
+	tenantID := uuid.NewString()
+
+	t.Run("given save should record span with tenant and payee ids", func(t *testing.T) {
+		exporter := recordSpans(t)
+		repository := tracing.NewPayeeRepository(newPayeeRepository(t))
+		payee := fake.New(1).Payee().MustBuild()
+
+		require.NoError(t, repository.Save(ctx, tenantID, payee))
+
+		spans := exporter.GetSpans()
+		require.Len(t, spans, 1)
+		assert.Equal(t, "PayeeRepository.Save", spans[0].Name)
+		assert.Equal(t, trace.SpanKindClient, spans[0].SpanKind)
+		assert.Contains(t, spans[0].Attributes, application.TenantIDAttribute.String(tenantID))
+		assert.Contains(t, spans[0].Attributes, application.PayeeIDAttribute.String(payee.ID()))
+		assert.Equal(t, codes.Unset, spans[0].Status.Code)
+	})
+
+	t.Run("given error should record it in span status", func(t *testing.T) {
+		exporter := recordSpans(t)
+		repository := tracing.NewPayeeRepository(newPayeeRepository(t))
+
+		_, err := repository.FindByID(ctx, tenantID, uuid.NewString())
+		require.ErrorIs(t, err, application.ErrPayeeNotFound)
+
+		spans := exporter.GetSpans()
+		require.Len(t, spans, 1)
+		assert.Equal(t, "PayeeRepository.FindByID", spans[0].Name)
+		assert.Equal(t, codes.Error, spans[0].Status.Code)
+		assert.Equal(t, application.ErrPayeeNotFound.Error(), spans[0].Status.Description)
+		require.Len(t, spans[0].Events, 1)
+		assert.Equal(t, "exception", spans[0].Events[0].Name)
+	})
+}
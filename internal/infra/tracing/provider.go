@@ -0,0 +1,29 @@
+package tracing
+
+import (
+	"io"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Setup registers provider as the global tracer provider and W3C trace context as the global propagator.
+// Tracers are bound to the first registered provider, so Setup must run once, before serving requests
+func Setup(provider trace.TracerProvider) {
+	otel.SetTracerProvider(provider)
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+}
+
+// NewStdoutProvider returns a provider that writes finished spans as json to w, for local runs.
+// Call Shutdown on provider to flush pending spans
+func NewStdoutProvider(w io.Writer) (*sdktrace.TracerProvider, error) {
+	exporter, err := stdouttrace.New(stdouttrace.WithWriter(w), stdouttrace.WithPrettyPrint())
+	if err != nil {
+		return nil, err
+	}
+
+	return sdktrace.NewTracerProvider(sdktrace.WithBatcher(exporter)), nil
+}
@@ -0,0 +1,4 @@
+// fake package generates valid test data for payee domain, such as CPF, CNPJ, pix keys and
+// payees, built on top of gofakeit. Values are reproducible when generated by a Faker created
+// with New(seed), or by package functions after seeding gofakeit.GlobalFaker with gofakeit.Seed
+package fake
@@ -0,0 +1,127 @@
+package fake
+
+import (
+	"strings"
+)
+
+var (
+	cpfFirstDigitWeights   = []int{10, 9, 8, 7, 6, 5, 4, 3, 2}
+	cpfSecondDigitWeights  = []int{11, 10, 9, 8, 7, 6, 5, 4, 3, 2}
+	cnpjFirstDigitWeights  = []int{5, 4, 3, 2, 9, 8, 7, 6, 5, 4, 3, 2}
+	cnpjSecondDigitWeights = []int{6, 5, 4, 3, 2, 9, 8, 7, 6, 5, 4, 3, 2}
+)
+
+// UnformattedCPF returns a valid CPF with only digits (Ex: 00000000000)
+func (f *Faker) UnformattedCPF() string {
+	values := f.digits(9)
+
+	for allSame(values) {
+		values = f.digits(9)
+	}
+
+	values = append(values, checkDigit(values, cpfFirstDigitWeights))
+	values = append(values, checkDigit(values, cpfSecondDigitWeights))
+
+	return join(values)
+}
+
+// CPF returns a valid formatted CPF (Ex: 000.000.000-00)
+func (f *Faker) CPF() string {
+	cpf := f.UnformattedCPF()
+	return cpf[:3] + "." + cpf[3:6] + "." + cpf[6:9] + "-" + cpf[9:]
+}
+
+// UnformattedCNPJ returns a valid numeric CNPJ with only digits (Ex: 00000000000100)
+func (f *Faker) UnformattedCNPJ() string {
+	values := append(f.digits(8), f.branch()...)
+
+	values = append(values, checkDigit(values, cnpjFirstDigitWeights))
+	values = append(values, checkDigit(values, cnpjSecondDigitWeights))
+
+	return join(values)
+}
+
+// CNPJ returns a valid formatted numeric CNPJ (Ex: 00.000.000/0001-00)
+func (f *Faker) CNPJ() string {
+	return formatCNPJ(f.UnformattedCNPJ())
+}
+
+const alphanumericCNPJCharacters = "0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZ"
+
+// AlphanumericCNPJ returns a valid formatted alphanumeric CNPJ (Ex: 12.ABC.345/01DE-35),
+// its check digits are calculated with ASCII code of characters minus 48
+func (f *Faker) AlphanumericCNPJ() string {
+	var builder strings.Builder
+	values := make([]int, 0, 14)
+
+	for range 12 {
+		char := alphanumericCNPJCharacters[f.faker.IntN(len(alphanumericCNPJCharacters))]
+		builder.WriteByte(char)
+		values = append(values, int(char)-'0')
+	}
+
+	values = append(values, checkDigit(values, cnpjFirstDigitWeights))
+	values = append(values, checkDigit(values, cnpjSecondDigitWeights))
+
+	return formatCNPJ(builder.String() + join(values[12:]))
+}
+
+// Document returns a valid formatted CPF or CNPJ
+func (f *Faker) Document() string {
+	if f.faker.Bool() {
+		return f.CPF()
+	}
+
+	return f.CNPJ()
+}
+
+// branch returns a random branch order of CNPJ, mostly the headquarters (0001)
+func (f *Faker) branch() []int {
+	if f.faker.IntN(4) > 0 {
+		return []int{0, 0, 0, 1}
+	}
+
+	order := f.faker.IntRange(2, 9999)
+	return []int{order / 1000, order / 100 % 10, order / 10 % 10, order % 10}
+}
+
+func formatCNPJ(cnpj string) string {
+	return cnpj[:2] + "." + cnpj[2:5] + "." + cnpj[5:8] + "/" + cnpj[8:12] + "-" + cnpj[12:]
+}
+
+func allSame(values []int) bool {
+	for _, value := range values {
+		if value != values[0] {
+			return false
+		}
+	}
+
+	return true
+}
+
+func join(values []int) string {
+	var builder strings.Builder
+	for _, value := range values {
+		builder.WriteByte(byte('0' + value))
+	}
+
+	return builder.String()
+}
+
+// UnformattedCPF returns a valid CPF with only digits (Ex: 00000000000)
+func UnformattedCPF() string { return global.UnformattedCPF() }
+
+// CPF returns a valid formatted CPF (Ex: 000.000.000-00)
+func CPF() string { return global.CPF() }
+
+// UnformattedCNPJ returns a valid numeric CNPJ with only digits (Ex: 00000000000100)
+func UnformattedCNPJ() string { return global.UnformattedCNPJ() }
+
+// CNPJ returns a valid formatted numeric CNPJ (Ex: 00.000.000/0001-00)
+func CNPJ() string { return global.CNPJ() }
+
+// AlphanumericCNPJ returns a valid formatted alphanumeric CNPJ (Ex: 12.ABC.345/01DE-35)
+func AlphanumericCNPJ() string { return global.AlphanumericCNPJ() }
+
+// Document returns a valid formatted CPF or CNPJ
+func Document() string { return global.Document() }
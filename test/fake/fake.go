@@ -0,0 +1,43 @@
+package fake
+
+import (
+	"github.com/brianvoe/gofakeit/v7"
+)
+
+// Faker generates valid values of payee domain, the same seed always generates the same values
+type Faker struct {
+	faker *gofakeit.Faker
+}
+
+// New returns a Faker seeded with seed, when seed is 0 a random seed is used
+func New(seed uint64) *Faker {
+	return &Faker{gofakeit.New(seed)}
+}
+
+// global backs package functions with gofakeit.GlobalFaker, so gofakeit.Seed makes them reproducible
+var global = &Faker{gofakeit.GlobalFaker}
+
+// digits returns n random digits
+func (f *Faker) digits(n int) []int {
+	values := make([]int, n)
+	for i := range values {
+		values[i] = f.faker.IntN(10)
+	}
+
+	return values
+}
+
+// checkDigit calculates a module 11 check digit of values, as used by CPF and CNPJ
+func checkDigit(values []int, weights []int) int {
+	sum := 0
+	for i, weight := range weights {
+		sum += values[i] * weight
+	}
+
+	digit := 11 - sum%11
+	if digit >= 10 {
+		return 0
+	}
+
+	return digit
+}
@@ -0,0 +1,105 @@
+package fake_test
+
+import (
+	"testing"
+
+	"github.com/italorfeitosa/payee-account-manager-api/internal/domain"
+	"github.com/italorfeitosa/payee-account-manager-api/test/fake"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testFakeMaxIterations = 100
+
+func TestFaker_Documents(t *testing.T) {
+	f := fake.New(0)
+
+	for i := 0; i < testFakeMaxIterations; i++ {
+		cpf, err := domain.NewCPF(f.CPF())
+		require.NoError(t, err)
+		assert.Regexp(t, `^\d{3}\.\d{3}\.\d{3}-\d{2}$`, cpf.String())
+
+		_, err = domain.NewCPF(f.UnformattedCPF())
+		require.NoError(t, err)
+
+		_, err = domain.NewCNPJ(f.CNPJ())
+		require.NoError(t, err)
+
+		_, err = domain.NewCNPJ(f.UnformattedCNPJ())
+		require.NoError(t, err)
+
+		_, err = domain.NewCNPJ(f.AlphanumericCNPJ())
+		require.NoError(t, err)
+
+		document := f.Document()
+		doc, err := domain.NewDocument(document)
+		require.NoError(t, err)
+		assert.Equal(t, document, doc.String())
+	}
+}
+
+func TestFaker_PixKey(t *testing.T) {
+	f := fake.New(0)
+
+	for i := 0; i < testFakeMaxIterations; i++ {
+		typ, value := f.PixKey()
+
+		key, err := domain.NewPixKey(typ, value)
+		require.NoError(t, err)
+		assert.Equal(t, value, key.String())
+	}
+}
+
+func TestFaker_Payee(t *testing.T) {
+	f := fake.New(0)
+
+	t.Run("should build valid draft payees", func(t *testing.T) {
+		for i := 0; i < testFakeMaxIterations; i++ {
+			payee, err := f.Payee().Build()
+
+			require.NoError(t, err)
+			assert.Equal(t, domain.PayeeDraftStatus, payee.Status())
+			assert.Nil(t, payee.BankAccount())
+		}
+	})
+
+	t.Run("given with methods should replace values", func(t *testing.T) {
+		payee := f.Payee().
+			WithName("Italo Feitosa").
+			WithDocument("99818083008").
+			WithEmail("").
+			WithPixKey(domain.EmailPixKeyType, "italo@feitosa.com").
+			WithAdditionalPixKey(domain.CPFPixKeyType, "99818083008").
+			WithStatus(domain.PayeeValidStatus).
+			MustBuild()
+
+		assert.Equal(t, "Italo Feitosa", payee.Name())
+		assert.Equal(t, "99818083008", payee.Document().Value())
+		assert.Equal(t, "", payee.Email())
+		assert.Equal(t, "italo@feitosa.com", payee.PixKey().Value())
+		assert.Len(t, payee.PixKeys(), 2)
+		assert.Equal(t, domain.PayeeValidStatus, payee.Status())
+		assert.NotNil(t, payee.BankAccount())
+	})
+
+	t.Run("given invalid values should return error", func(t *testing.T) {
+		_, err := f.Payee().WithDocument("123").Build()
+
+		assert.ErrorIs(t, err, domain.ErrInvalidDocument)
+	})
+}
+
+func TestFaker_Reproducible(t *testing.T) {
+	generate := func() []any {
+		f := fake.New(42)
+		typ, value := f.PixKey()
+		payee := f.Payee().WithStatus(domain.PayeeValidStatus).MustBuild()
+
+		return []any{
+			f.CPF(), f.CNPJ(), f.AlphanumericCNPJ(), f.Telefone(), f.Email(), f.ChaveAleatoria(), typ, value,
+			payee.ID(), payee.Name(), payee.Document(), payee.PixKeys(), payee.BankAccount(), payee.CreatedAt(),
+		}
+	}
+
+	assert.Equal(t, generate(), generate())
+}
@@ -0,0 +1,178 @@
+package fake
+
+import (
+	"context"
+	"time"
+
+	"github.com/italorfeitosa/payee-account-manager-api/internal/domain"
+)
+
+// Name returns a valid person name, with first and last names
+func (f *Faker) Name() string {
+	for {
+		name := f.faker.Name()
+		if _, err := domain.NewName(name); err == nil {
+			return name
+		}
+	}
+}
+
+// BankAccount returns a random bank account
+func (f *Faker) BankAccount() *domain.BankAccount {
+	return &domain.BankAccount{
+		AccountType:   f.faker.RandomString([]string{"CONTA_CORRENTE", "CONTA_POUPANCA"}),
+		AccountNumber: join(f.digits(8)),
+		AccountDigit:  join(f.digits(1)),
+		BranchNumber:  join(f.digits(4)),
+		BankCode:      join(f.digits(3)),
+		BankIspb:      join(f.digits(8)),
+	}
+}
+
+// Name returns a valid person name, with first and last names
+func Name() string { return global.Name() }
+
+// BankAccount returns a random bank account
+func BankAccount() *domain.BankAccount { return global.BankAccount() }
+
+// payees are created between createdAtStart and createdAtEnd, fixed bounds keep timestamps reproducible
+var (
+	createdAtStart = time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC)
+	createdAtEnd   = time.Date(2026, time.January, 1, 0, 0, 0, 0, time.UTC)
+)
+
+type pixKeyValue struct {
+	typ   string
+	value string
+}
+
+// PayeeBuilder builds valid domain.PayeeEntity instances filled with random values,
+// which can be replaced by With methods
+type PayeeBuilder struct {
+	faker             *Faker
+	name              string
+	tradeName         string
+	document          string
+	email             string
+	pixKey            pixKeyValue
+	additionalPixKeys []pixKeyValue
+	status            domain.PayeeStatus
+	bankAccount       *domain.BankAccount
+}
+
+// Payee returns a PayeeBuilder with random values, status is DRAFT by default
+func (f *Faker) Payee() *PayeeBuilder {
+	pixKeyType, pixKey := f.PixKey()
+
+	return &PayeeBuilder{
+		faker:    f,
+		name:     f.Name(),
+		document: f.Document(),
+		email:    f.Email(),
+		pixKey:   pixKeyValue{pixKeyType, pixKey},
+		status:   domain.PayeeDraftStatus,
+	}
+}
+
+// Payee returns a PayeeBuilder with random values, status is DRAFT by default
+func Payee() *PayeeBuilder { return global.Payee() }
+
+func (b *PayeeBuilder) WithName(name string) *PayeeBuilder {
+	b.name = name
+	return b
+}
+
+// WithTradeName sets the nome fantasia, document must be a CNPJ
+func (b *PayeeBuilder) WithTradeName(tradeName string) *PayeeBuilder {
+	b.tradeName = tradeName
+	return b
+}
+
+func (b *PayeeBuilder) WithDocument(document string) *PayeeBuilder {
+	b.document = document
+	return b
+}
+
+// WithEmail replaces the email, it can be empty
+func (b *PayeeBuilder) WithEmail(email string) *PayeeBuilder {
+	b.email = email
+	return b
+}
+
+// WithPixKey replaces the primary pix key
+func (b *PayeeBuilder) WithPixKey(pixKeyType, pixKey string) *PayeeBuilder {
+	b.pixKey = pixKeyValue{pixKeyType, pixKey}
+	return b
+}
+
+// WithAdditionalPixKey appends a non primary pix key
+func (b *PayeeBuilder) WithAdditionalPixKey(pixKeyType, pixKey string) *PayeeBuilder {
+	b.additionalPixKeys = append(b.additionalPixKeys, pixKeyValue{pixKeyType, pixKey})
+	return b
+}
+
+// WithStatus replaces the status, when status is not DRAFT a random bank account is generated,
+// unless one is set with WithBankAccount
+func (b *PayeeBuilder) WithStatus(status domain.PayeeStatus) *PayeeBuilder {
+	b.status = status
+	return b
+}
+
+func (b *PayeeBuilder) WithBankAccount(bankAccount *domain.BankAccount) *PayeeBuilder {
+	b.bankAccount = bankAccount
+	return b
+}
+
+// Build validates the values with domain rules and returns the payee,
+// id and timestamps are generated by faker too, to keep payee reproducible
+func (b *PayeeBuilder) Build() (*domain.PayeeEntity, error) {
+	payee, err := domain.CreatePayee(b.name, b.document, b.pixKey.typ, b.pixKey.value, b.email, domain.WithTradeName(b.tradeName))
+	if err != nil {
+		return nil, err
+	}
+
+	for _, key := range b.additionalPixKeys {
+		if err := payee.AddPixKey(key.typ, key.value); err != nil {
+			return nil, err
+		}
+	}
+
+	bankAccount := b.bankAccount
+	if bankAccount == nil && b.status != domain.PayeeDraftStatus {
+		bankAccount = b.faker.BankAccount()
+	}
+
+	createdAt := b.faker.faker.DateRange(createdAtStart, createdAtEnd).Truncate(time.Millisecond)
+	opts := []domain.RestoreOption{
+		domain.RestoreTimestamps(createdAt, createdAt),
+		domain.RestoreTradeName(payee.TradeName()),
+	}
+
+	pixKeys := payee.PixKeys()
+	for _, key := range pixKeys[1:] {
+		opts = append(opts, domain.RestoreAdditionalPixKey(key.Type(), key.Value()))
+	}
+
+	return domain.RestorePayee(
+		context.Background(),
+		b.faker.faker.UUID(),
+		payee.Name(),
+		payee.Document().Value(),
+		b.status.Value(),
+		payee.Email(),
+		pixKeys[0].Type(),
+		pixKeys[0].Value(),
+		bankAccount,
+		opts...,
+	), nil
+}
+
+// MustBuild is like Build but panics if values are not valid
+func (b *PayeeBuilder) MustBuild() *domain.PayeeEntity {
+	payee, err := b.Build()
+	if err != nil {
+		panic(err)
+	}
+
+	return payee
+}
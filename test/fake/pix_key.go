@@ -0,0 +1,65 @@
+package fake
+
+import (
+	"fmt"
+
+	"github.com/italorfeitosa/payee-account-manager-api/internal/domain"
+)
+
+// ddds lists the area codes accepted by domain, in ascending order to keep generation reproducible
+var ddds = func() []string {
+	var ddds []string
+	for i := 11; i <= 99; i++ {
+		ddd := fmt.Sprint(i)
+		if _, ok := domain.DDDState(ddd); ok {
+			ddds = append(ddds, ddd)
+		}
+	}
+
+	return ddds
+}()
+
+// Telefone returns a valid mobile telefone with country code (Ex: +5511912345678)
+func (f *Faker) Telefone() string {
+	ddd := ddds[f.faker.IntN(len(ddds))]
+	return "+55" + ddd + "9" + join(f.digits(8))
+}
+
+// Email returns a valid email address (Ex: italofeitosa@silva.com)
+func (f *Faker) Email() string {
+	return f.faker.Email()
+}
+
+// ChaveAleatoria returns a valid random pix key, a lower case uuid
+func (f *Faker) ChaveAleatoria() string {
+	return f.faker.UUID()
+}
+
+// PixKey returns a random pix key type and a valid formatted value of the type,
+// the value is equal to String() of domain.PixKey
+func (f *Faker) PixKey() (string, string) {
+	switch f.faker.IntN(5) {
+	case 0:
+		return domain.CPFPixKeyType, f.CPF()
+	case 1:
+		return domain.CNPJPixKeyType, f.CNPJ()
+	case 2:
+		return domain.TelefonePixKeyType, f.Telefone()
+	case 3:
+		return domain.EmailPixKeyType, f.Email()
+	default:
+		return domain.ChaveAleatoriaPixKeyType, f.ChaveAleatoria()
+	}
+}
+
+// Telefone returns a valid mobile telefone with country code (Ex: +5511912345678)
+func Telefone() string { return global.Telefone() }
+
+// Email returns a valid email address (Ex: italofeitosa@silva.com)
+func Email() string { return global.Email() }
+
+// ChaveAleatoria returns a valid random pix key, a lower case uuid
+func ChaveAleatoria() string { return global.ChaveAleatoria() }
+
+// PixKey returns a random pix key type and a valid formatted value of the type
+func PixKey() (string, string) { return global.PixKey() }